@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 
@@ -13,111 +14,700 @@ import (
 
 	"net"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 
 	"github.com/Financial-Times/go-fthealth/v1_1"
 	"github.com/Financial-Times/go-logger"
 	"github.com/gorilla/mux"
 	cli "github.com/jawher/mow.cli"
+	"google.golang.org/grpc"
 )
 
 const appDescription = "This service reads people from Neo4j"
 
 func main() {
 	app := cli.App("public-people-api", "A public RESTful API for accessing People in neo4j")
-	appSystemCode := app.String(cli.StringOpt{
+	configFile := app.String(cli.StringOpt{
+		Name:   "config-file",
+		Value:  "",
+		Desc:   "YAML or JSON file of option values keyed by option name. Flags and environment variables take precedence",
+		EnvVar: "CONFIG_FILE",
+	})
+	printConfig := app.Bool(cli.BoolOpt{
+		Name:  "print-config",
+		Value: false,
+		Desc:  "Print the effective configuration, with secrets redacted, and exit",
+	})
+	opts := newOptions(app)
+	appSystemCode := opts.String(cli.StringOpt{
 		Name:   "app-system-code",
 		Value:  "public-people-api",
 		Desc:   "System Code of the application",
 		EnvVar: "APP_SYSTEM_CODE",
 	})
-	appName := app.String(cli.StringOpt{
+	appName := opts.String(cli.StringOpt{
 		Name:   "app-name",
 		Value:  "Public People API",
 		Desc:   "Application name",
 		EnvVar: "APP_NAME",
 	})
-	logLevel := app.String(cli.StringOpt{
+	logLevel := opts.String(cli.StringOpt{
 		Name:   "log-level",
 		Value:  "INFO",
 		Desc:   "Log level to use",
 		EnvVar: "LOG_LEVEL",
 	})
-	port := app.String(cli.StringOpt{
+	port := opts.String(cli.StringOpt{
 		Name:   "port",
 		Value:  "8080",
 		Desc:   "Port to listen on",
 		EnvVar: "APP_PORT",
 	})
-	cacheDuration := app.String(cli.StringOpt{
+	grpcPort := opts.String(cli.StringOpt{
+		Name:   "grpc-port",
+		Value:  "9090",
+		Desc:   "Port the gRPC API listens on",
+		EnvVar: "GRPC_PORT",
+	})
+	adminPort := opts.String(cli.StringOpt{
+		Name:   "admin-port",
+		Value:  "",
+		Desc:   "Port of the admin listener serving pprof, expvar, runtime stats, healthchecks, metrics and the debug endpoint. Empty disables it",
+		EnvVar: "ADMIN_PORT",
+	})
+	cacheDuration := opts.String(cli.StringOpt{
 		Name:   "cache-duration",
 		Value:  "30s",
 		Desc:   "Duration Get requests should be cached for. e.g. 2h45m would set the max-age value to '7440' seconds",
 		EnvVar: "CACHE_DURATION",
 	})
-	requestLoggingEnabled := app.Bool(cli.BoolOpt{
+	requestLoggingEnabled := opts.Bool(cli.BoolOpt{
 		Name:   "requestLoggingEnabled",
 		Value:  true,
 		Desc:   "Whether to log requests",
 		EnvVar: "REQUEST_LOGGING_ENABLED",
 	})
-	publicConceptsApiURL := app.String(cli.StringOpt{
+	accessLogSampleRate := opts.String(cli.StringOpt{
+		Name:   "access-log-sample-rate",
+		Value:  "1",
+		Desc:   "Fraction of requests, between 0 and 1, that get a structured access log entry. Independent of requestLoggingEnabled",
+		EnvVar: "ACCESS_LOG_SAMPLE_RATE",
+	})
+	debugToken := opts.Secret(cli.StringOpt{
+		Name:   "debug-token",
+		Value:  "",
+		Desc:   "Bearer token required by /__debug/people/{uuid} on the admin listener, which requires --admin-port. Empty disables the debug endpoint",
+		EnvVar: "DEBUG_TOKEN",
+	})
+	healthCheckInterval := opts.String(cli.StringOpt{
+		Name:   "health-check-interval",
+		Value:  "10s",
+		Desc:   "How often healthchecks run in the background; /__health and /__gtg serve the latest results. 0 runs them on every request",
+		EnvVar: "HEALTH_CHECK_INTERVAL",
+	})
+	healthCheckStaleAfter := opts.String(cli.StringOpt{
+		Name:   "health-check-stale-after",
+		Value:  "1m",
+		Desc:   "Age after which a background healthcheck result fails /__health and /__gtg. 0 disables the staleness check",
+		EnvVar: "HEALTH_CHECK_STALE_AFTER",
+	})
+	shutdownGracePeriod := opts.String(cli.StringOpt{
+		Name:   "shutdown-grace-period",
+		Value:  "5s",
+		Desc:   "How long the service keeps serving after SIGTERM with readiness failing, so load balancers stop sending traffic",
+		EnvVar: "SHUTDOWN_GRACE_PERIOD",
+	})
+	shutdownTimeout := opts.String(cli.StringOpt{
+		Name:   "shutdown-timeout",
+		Value:  "10s",
+		Desc:   "How long in-flight requests are given to complete once the grace period is over",
+		EnvVar: "SHUTDOWN_TIMEOUT",
+	})
+	canaryPersonUUID := opts.String(cli.StringOpt{
+		Name:   "canary-person-uuid",
+		Value:  "",
+		Desc:   "UUID of a known person that is periodically retrieved end to end as a deep healthcheck. Empty disables the check",
+		EnvVar: "CANARY_PERSON_UUID",
+	})
+	canaryInterval := opts.String(cli.StringOpt{
+		Name:   "canary-interval",
+		Value:  "1m",
+		Desc:   "How often the canary person is retrieved",
+		EnvVar: "CANARY_INTERVAL",
+	})
+	publicConceptsApiURL := opts.String(cli.StringOpt{
 		Name:   "publicConceptsApiURL",
 		Value:  "http://localhost:8080",
 		Desc:   "Public concepts API endpoint URL.",
 		EnvVar: "CONCEPTS_API",
 	})
+	conceptSource := opts.String(cli.StringOpt{
+		Name:   "concept-source",
+		Value:  people.ConceptSourceHTTP,
+		Desc:   "Where concepts are read from: http for public-concepts-api, file for a directory read on every request, or memory for a snapshot loaded at startup",
+		EnvVar: "CONCEPT_SOURCE",
+	})
+	conceptSourcePath := opts.String(cli.StringOpt{
+		Name:   "concept-source-path",
+		Value:  "",
+		Desc:   "Directory of <uuid>.json concepts and concordances.json for the file and memory sources, or a JSON array of concepts for the memory source",
+		EnvVar: "CONCEPT_SOURCE_PATH",
+	})
 
-	logger.InitLogger(*appSystemCode, *logLevel)
-	logger.Infof("[Startup] public-people-api is starting ")
+	clientTimeout := opts.String(cli.StringOpt{
+		Name:   "client-timeout",
+		Value:  "10s",
+		Desc:   "Overall timeout of a request to public-concepts-api, including reading the body. 0 disables it",
+		EnvVar: "CLIENT_TIMEOUT",
+	})
+	clientDialTimeout := opts.String(cli.StringOpt{
+		Name:   "client-dial-timeout",
+		Value:  "10s",
+		Desc:   "Timeout for connecting to public-concepts-api",
+		EnvVar: "CLIENT_DIAL_TIMEOUT",
+	})
+	clientKeepAlive := opts.String(cli.StringOpt{
+		Name:   "client-keep-alive",
+		Value:  "60s",
+		Desc:   "Keep-alive period of connections to public-concepts-api",
+		EnvVar: "CLIENT_KEEP_ALIVE",
+	})
+	clientMaxIdleConns := opts.String(cli.StringOpt{
+		Name:   "client-max-idle-conns",
+		Value:  "20",
+		Desc:   "Maximum number of idle connections kept open",
+		EnvVar: "CLIENT_MAX_IDLE_CONNS",
+	})
+	clientMaxIdleConnsPerHost := opts.String(cli.StringOpt{
+		Name:   "client-max-idle-conns-per-host",
+		Value:  "20",
+		Desc:   "Maximum number of idle connections kept open to public-concepts-api",
+		EnvVar: "CLIENT_MAX_IDLE_CONNS_PER_HOST",
+	})
+	clientIdleConnTimeout := opts.String(cli.StringOpt{
+		Name:   "client-idle-conn-timeout",
+		Value:  "60s",
+		Desc:   "How long an idle connection is kept open",
+		EnvVar: "CLIENT_IDLE_CONN_TIMEOUT",
+	})
+	clientTLSHandshakeTimeout := opts.String(cli.StringOpt{
+		Name:   "client-tls-handshake-timeout",
+		Value:  "3s",
+		Desc:   "Timeout for the TLS handshake with public-concepts-api",
+		EnvVar: "CLIENT_TLS_HANDSHAKE_TIMEOUT",
+	})
+	clientResponseHeaderTimeout := opts.String(cli.StringOpt{
+		Name:   "client-response-header-timeout",
+		Value:  "5s",
+		Desc:   "Timeout for public-concepts-api to start responding once the request is sent. 0 disables it",
+		EnvVar: "CLIENT_RESPONSE_HEADER_TIMEOUT",
+	})
+	clientExpectContinueTimeout := opts.String(cli.StringOpt{
+		Name:   "client-expect-continue-timeout",
+		Value:  "1s",
+		Desc:   "Timeout for a 100-continue response from public-concepts-api",
+		EnvVar: "CLIENT_EXPECT_CONTINUE_TIMEOUT",
+	})
+	clientCABundle := opts.String(cli.StringOpt{
+		Name:   "client-ca-bundle",
+		Value:  "",
+		Desc:   "PEM file of CA certificates trusted for public-concepts-api in addition to the system roots",
+		EnvVar: "CLIENT_CA_BUNDLE",
+	})
+	clientCert := opts.String(cli.StringOpt{
+		Name:   "client-cert",
+		Value:  "",
+		Desc:   "PEM client certificate presented to public-concepts-api for mTLS",
+		EnvVar: "CLIENT_CERT",
+	})
+	clientKey := opts.String(cli.StringOpt{
+		Name:   "client-key",
+		Value:  "",
+		Desc:   "PEM private key of the client certificate",
+		EnvVar: "CLIENT_KEY",
+	})
+	serverReadTimeout := opts.String(cli.StringOpt{
+		Name:   "server-read-timeout",
+		Value:  "10s",
+		Desc:   "Timeout for reading a whole request, including the body",
+		EnvVar: "SERVER_READ_TIMEOUT",
+	})
+	serverReadHeaderTimeout := opts.String(cli.StringOpt{
+		Name:   "server-read-header-timeout",
+		Value:  "5s",
+		Desc:   "Timeout for reading the request headers, also used by the admin listener",
+		EnvVar: "SERVER_READ_HEADER_TIMEOUT",
+	})
+	serverWriteTimeout := opts.String(cli.StringOpt{
+		Name:   "server-write-timeout",
+		Value:  "10s",
+		Desc:   "Timeout for writing the response, from the end of the request headers",
+		EnvVar: "SERVER_WRITE_TIMEOUT",
+	})
+	serverIdleTimeout := opts.String(cli.StringOpt{
+		Name:   "server-idle-timeout",
+		Value:  "60s",
+		Desc:   "How long idle keep-alive connections are kept open",
+		EnvVar: "SERVER_IDLE_TIMEOUT",
+	})
+	rateLimit := opts.String(cli.StringOpt{
+		Name:   "rate-limit",
+		Value:  "0",
+		Desc:   "Requests per second allowed for each client without its own limit, identified by API key or IP address. 0 disables rate limiting for them",
+		EnvVar: "RATE_LIMIT",
+	})
+	rateLimitBurst := opts.String(cli.StringOpt{
+		Name:   "rate-limit-burst",
+		Value:  "20",
+		Desc:   "Burst of requests allowed for each client without its own limit",
+		EnvVar: "RATE_LIMIT_BURST",
+	})
+	rateLimitKeyHeader := opts.String(cli.StringOpt{
+		Name:   "rate-limit-key-header",
+		Value:  "X-Api-Key",
+		Desc:   "Header holding the API key that identifies a client for rate limiting when authentication is disabled. Clients without one are identified by IP address",
+		EnvVar: "RATE_LIMIT_KEY_HEADER",
+	})
+	rateLimitClients := opts.Secret(cli.StringOpt{
+		Name:   "rate-limit-clients",
+		Value:  "",
+		Desc:   "Comma separated clients with their own rate limit, in the form name:rate:burst with the principal name when authentication is enabled, or name:apikey:rate:burst otherwise",
+		EnvVar: "RATE_LIMIT_CLIENTS",
+	})
+	rateLimitTrustForwardedFor := opts.Bool(cli.BoolOpt{
+		Name:   "rate-limit-trust-forwarded-for",
+		Value:  false,
+		Desc:   "Identify clients by the first X-Forwarded-For address rather than the connection address",
+		EnvVar: "RATE_LIMIT_TRUST_FORWARDED_FOR",
+	})
+	redirectPolicy := opts.String(cli.StringOpt{
+		Name:   "redirect-policy",
+		Value:  "301",
+		Desc:   "How requests for concorded UUIDs are answered: 301 or 308 redirects, or transparent to serve the canonical person",
+		EnvVar: "REDIRECT_POLICY",
+	})
+	deprecatedPolicy := opts.String(cli.StringOpt{
+		Name:   "deprecated-policy",
+		Value:  "serve",
+		Desc:   "How requests for deprecated people are answered: serve them, hide them with a 404, gone with a 410, or successor to redirect to the person superseding them",
+		EnvVar: "DEPRECATED_POLICY",
+	})
+	deprecatedSunset := opts.String(cli.StringOpt{
+		Name:   "deprecated-sunset",
+		Value:  "",
+		Desc:   "RFC 3339 date deprecated people stop being served, announced in the Sunset header",
+		EnvVar: "DEPRECATED_SUNSET",
+	})
+	concordanceDepth := opts.String(cli.StringOpt{
+		Name:   "concordance-depth",
+		Value:  "5",
+		Desc:   "Number of canonical UUIDs followed to resolve a concorded person before its chain is an error",
+		EnvVar: "CONCORDANCE_DEPTH",
+	})
+	compressionEncodings := opts.String(cli.StringOpt{
+		Name:   "compression-encodings",
+		Value:  "br, gzip",
+		Desc:   "Comma separated content encodings of compressed responses, br and gzip, in order of preference. Empty disables compression",
+		EnvVar: "COMPRESSION_ENCODINGS",
+	})
+	compressionMinSize := opts.String(cli.StringOpt{
+		Name:   "compression-min-size",
+		Value:  "1024",
+		Desc:   "Size in bytes under which responses are not compressed",
+		EnvVar: "COMPRESSION_MIN_SIZE",
+	})
+	corsAllowedOrigins := opts.String(cli.StringOpt{
+		Name:   "cors-allowed-origins",
+		Value:  "",
+		Desc:   "Comma separated origins allowed to call the API from browsers, such as https://*.ft.com, or *. Empty disables CORS",
+		EnvVar: "CORS_ALLOWED_ORIGINS",
+	})
+	corsAllowedMethods := opts.String(cli.StringOpt{
+		Name:   "cors-allowed-methods",
+		Value:  "GET, HEAD",
+		Desc:   "Comma separated methods allowed cross-origin",
+		EnvVar: "CORS_ALLOWED_METHODS",
+	})
+	corsAllowedHeaders := opts.String(cli.StringOpt{
+		Name:   "cors-allowed-headers",
+		Value:  "Accept, Authorization, X-Api-Key, X-Request-Id",
+		Desc:   "Comma separated request headers allowed cross-origin, or * for any",
+		EnvVar: "CORS_ALLOWED_HEADERS",
+	})
+	corsExposedHeaders := opts.String(cli.StringOpt{
+		Name:   "cors-exposed-headers",
+		Value:  "X-Request-Id, X-Canonical-Id",
+		Desc:   "Comma separated response headers readable cross-origin",
+		EnvVar: "CORS_EXPOSED_HEADERS",
+	})
+	corsMaxAge := opts.String(cli.StringOpt{
+		Name:   "cors-max-age",
+		Value:  "10m",
+		Desc:   "Duration browsers may cache preflight responses for",
+		EnvVar: "CORS_MAX_AGE",
+	})
+	corsAllowCredentials := opts.Bool(cli.BoolOpt{
+		Name:   "cors-allow-credentials",
+		Value:  false,
+		Desc:   "Allow cross-origin requests with cookies or authorization",
+		EnvVar: "CORS_ALLOW_CREDENTIALS",
+	})
+	authAPIKeys := opts.Secret(cli.StringOpt{
+		Name:   "auth-api-keys",
+		Value:  "",
+		Desc:   "Comma separated API keys in the form name:key:scopes, with space separated scopes such as people:pii",
+		EnvVar: "AUTH_API_KEYS",
+	})
+	authAPIKeysFile := opts.String(cli.StringOpt{
+		Name:   "auth-api-keys-file",
+		Value:  "",
+		Desc:   "File of API keys in the form name:key:scopes, one per line",
+		EnvVar: "AUTH_API_KEYS_FILE",
+	})
+	authKeyHeader := opts.String(cli.StringOpt{
+		Name:   "auth-key-header",
+		Value:  "X-Api-Key",
+		Desc:   "Header holding the API key of a client",
+		EnvVar: "AUTH_KEY_HEADER",
+	})
+	authJWKSFile := opts.String(cli.StringOpt{
+		Name:   "auth-jwks-file",
+		Value:  "",
+		Desc:   "JWKS file of the keys bearer JWTs are verified with. Empty disables JWT authentication",
+		EnvVar: "AUTH_JWKS_FILE",
+	})
+	authJWTIssuer := opts.String(cli.StringOpt{
+		Name:   "auth-jwt-issuer",
+		Value:  "",
+		Desc:   "Required iss claim of JWTs. Empty accepts any issuer",
+		EnvVar: "AUTH_JWT_ISSUER",
+	})
+	authJWTAudience := opts.String(cli.StringOpt{
+		Name:   "auth-jwt-audience",
+		Value:  "",
+		Desc:   "Required aud claim of JWTs. Empty accepts any audience",
+		EnvVar: "AUTH_JWT_AUDIENCE",
+	})
+	authRequired := opts.Bool(cli.BoolOpt{
+		Name:   "auth-required",
+		Value:  false,
+		Desc:   "Reject requests without credentials. Otherwise they are anonymous",
+		EnvVar: "AUTH_REQUIRED",
+	})
+	authAnonymousScopes := opts.String(cli.StringOpt{
+		Name:   "auth-anonymous-scopes",
+		Value:  "",
+		Desc:   "Space separated scopes granted to anonymous requests",
+		EnvVar: "AUTH_ANONYMOUS_SCOPES",
+	})
+	redactionPolicyFile := opts.String(cli.StringOpt{
+		Name:   "redaction-policy-file",
+		Value:  "",
+		Desc:   "YAML or JSON file of rules hiding or masking person fields, for every person and client or for some of them",
+		EnvVar: "REDACTION_POLICY_FILE",
+	})
+	imageServiceURLTemplate := opts.String(cli.StringOpt{
+		Name:   "image-service-url-template",
+		Value:  "https://www.ft.com/__origami/service/image/v2/images/raw/{url}?source=public-people-api&width={width}&height={height}&format={format}&fit=cover",
+		Desc:   "URL template for person image renditions, with {url}, {width}, {height} and {format} placeholders. Empty disables image sets",
+		EnvVar: "IMAGE_SERVICE_URL_TEMPLATE",
+	})
+	imageRenditions := opts.String(cli.StringOpt{
+		Name:   "image-renditions",
+		Value:  "square-small:100x100:jpg,square-medium:240x240:jpg,square-large:480x480:jpg,landscape:640x360:jpg,square-medium-webp:240x240:webp",
+		Desc:   "Comma separated image renditions in the form name:WIDTHxHEIGHT:format",
+		EnvVar: "IMAGE_RENDITIONS",
+	})
+	tracingExporter := opts.String(cli.StringOpt{
+		Name:   "tracing-exporter",
+		Value:  people.TracingExporterNone,
+		Desc:   "OpenTelemetry trace exporter: none, stdout or otlp",
+		EnvVar: "TRACING_EXPORTER",
+	})
+	tracingEndpoint := opts.String(cli.StringOpt{
+		Name:   "tracing-endpoint",
+		Value:  "",
+		Desc:   "OTLP/HTTP traces endpoint URL, e.g. http://localhost:4318/v1/traces. Defaults to the standard OTEL_EXPORTER_OTLP_* environment variables",
+		EnvVar: "TRACING_ENDPOINT",
+	})
+	tracingSampleRatio := opts.String(cli.StringOpt{
+		Name:   "tracing-sample-ratio",
+		Value:  "1",
+		Desc:   "Fraction of traces started by this service that are sampled, between 0 and 1",
+		EnvVar: "TRACING_SAMPLE_RATIO",
+	})
 
 	app.Action = func() {
+		if *configFile != "" {
+			if err := opts.LoadFile(*configFile); err != nil {
+				logger.Fatalf("Invalid config file:\n%v", err)
+			}
+		}
+		logger.InitLogger(*appSystemCode, *logLevel)
+		logger.Infof("[Startup] public-people-api is starting ")
+		if *printConfig {
+			if err := opts.Print(os.Stdout); err != nil {
+				logger.Fatalf("Failed to print config, %v", err)
+			}
+		}
+
+		v := &validator{}
+		v.port("port", *port, false)
+		v.port("grpc-port", *grpcPort, false)
+		v.port("admin-port", *adminPort, true)
+		if *debugToken != "" && *adminPort == "" {
+			v.check("debug-token", errors.New("the debug endpoint is only served on the admin listener, which requires --admin-port"))
+		}
+		v.url("publicConceptsApiURL", *publicConceptsApiURL, false)
+		v.url("tracing-endpoint", *tracingEndpoint, true)
+		v.oneOf("tracing-exporter", *tracingExporter, people.TracingExporterNone, people.TracingExporterStdout, people.TracingExporterOTLP)
+		cacheDuration := v.duration("cache-duration", *cacheDuration, 0)
+		checkInterval := v.duration("health-check-interval", *healthCheckInterval, 0)
+		checkStaleAfter := v.duration("health-check-stale-after", *healthCheckStaleAfter, 0)
+		gracePeriod := v.duration("shutdown-grace-period", *shutdownGracePeriod, 0)
+		drainTimeout := v.duration("shutdown-timeout", *shutdownTimeout, time.Millisecond)
+		canaryInterval := v.duration("canary-interval", *canaryInterval, time.Second)
+		sampleRatio := v.fraction("tracing-sample-ratio", *tracingSampleRatio)
+		accessLogRate := v.fraction("access-log-sample-rate", *accessLogSampleRate)
+		httpClientConfig := clientConfig{
+			Timeout:               v.duration("client-timeout", *clientTimeout, 0),
+			DialTimeout:           v.duration("client-dial-timeout", *clientDialTimeout, 0),
+			KeepAlive:             v.duration("client-keep-alive", *clientKeepAlive, 0),
+			MaxIdleConns:          v.positiveInt("client-max-idle-conns", *clientMaxIdleConns),
+			MaxIdleConnsPerHost:   v.positiveInt("client-max-idle-conns-per-host", *clientMaxIdleConnsPerHost),
+			IdleConnTimeout:       v.duration("client-idle-conn-timeout", *clientIdleConnTimeout, 0),
+			TLSHandshakeTimeout:   v.duration("client-tls-handshake-timeout", *clientTLSHandshakeTimeout, 0),
+			ResponseHeaderTimeout: v.duration("client-response-header-timeout", *clientResponseHeaderTimeout, 0),
+			ExpectContinueTimeout: v.duration("client-expect-continue-timeout", *clientExpectContinueTimeout, 0),
+			CABundle:              *clientCABundle,
+			ClientCert:            *clientCert,
+			ClientKey:             *clientKey,
+		}
+		httpServerConfig := serverConfig{
+			ReadTimeout:       v.duration("server-read-timeout", *serverReadTimeout, 0),
+			ReadHeaderTimeout: v.duration("server-read-header-timeout", *serverReadHeaderTimeout, 0),
+			WriteTimeout:      v.duration("server-write-timeout", *serverWriteTimeout, 0),
+			IdleTimeout:       v.duration("server-idle-timeout", *serverIdleTimeout, 0),
+		}
+		c, err := newHTTPClient(httpClientConfig)
+		v.check("public-concepts-api client TLS settings", err)
+		rateLimitConfig := people.RateLimitConfig{
+			KeyHeader: *rateLimitKeyHeader,
+			Default: people.RateLimit{
+				Rate:  v.nonNegative("rate-limit", *rateLimit),
+				Burst: v.positiveInt("rate-limit-burst", *rateLimitBurst),
+			},
+			TrustForwardedFor: *rateLimitTrustForwardedFor,
+		}
+		rateLimitConfig.Clients, err = people.ParseRateLimitClients(*rateLimitClients)
+		v.check("rate-limit-clients", err)
+		redirects, err := people.ParseRedirectPolicy(*redirectPolicy)
+		v.check("redirect-policy", err)
+		depth := v.positiveInt("concordance-depth", *concordanceDepth)
+		v.oneOf("concept-source", *conceptSource, people.ConceptSourceHTTP, people.ConceptSourceFile, people.ConceptSourceMemory)
+		var source people.ConceptSource
+		switch {
+		case *conceptSource == people.ConceptSourceHTTP:
+		case *conceptSourcePath == "":
+			v.fail("concept-source-path", *conceptSourcePath, "a path, as the concept source is "+*conceptSource)
+		case *conceptSource == people.ConceptSourceFile:
+			fileSource, err := people.NewFileConceptSource(*conceptSourcePath)
+			v.check("concept-source-path", err)
+			if err == nil {
+				source = fileSource
+			}
+		case *conceptSource == people.ConceptSourceMemory:
+			memorySource, err := people.LoadMemoryConceptSource(*conceptSourcePath)
+			v.check("concept-source-path", err)
+			if err == nil {
+				logger.Infof("Loaded %d concepts into memory from %s", memorySource.Len(), *conceptSourcePath)
+				source = memorySource
+			}
+		}
+		deprecated, err := people.ParseDeprecatedPolicy(*deprecatedPolicy)
+		v.check("deprecated-policy", err)
+		var sunset time.Time
+		if *deprecatedSunset != "" {
+			sunset, err = time.Parse(time.RFC3339, *deprecatedSunset)
+			v.check("deprecated-sunset", err)
+		}
+		compressionConfig := people.CompressionConfig{
+			MinSize: int(v.nonNegative("compression-min-size", *compressionMinSize)),
+		}
+		compressionConfig.Encodings, err = people.ParseCompressionEncodings(*compressionEncodings)
+		v.check("compression-encodings", err)
+		corsConfig := people.CORSConfig{
+			AllowedMethods:   people.SplitList(*corsAllowedMethods),
+			AllowedHeaders:   people.SplitList(*corsAllowedHeaders),
+			ExposedHeaders:   people.SplitList(*corsExposedHeaders),
+			MaxAge:           v.duration("cors-max-age", *corsMaxAge, 0),
+			AllowCredentials: *corsAllowCredentials,
+		}
+		corsConfig.AllowedOrigins, err = people.ParseCORSOrigins(*corsAllowedOrigins)
+		v.check("cors-allowed-origins", err)
+		if corsConfig.AllowCredentials && slices.Contains(corsConfig.AllowedOrigins, "*") {
+			v.fail("cors-allowed-origins", *corsAllowedOrigins, "explicit origins with --cors-allow-credentials, not *")
+		}
+		authConfig := people.AuthConfig{
+			KeyHeader:       *authKeyHeader,
+			Issuer:          *authJWTIssuer,
+			Audience:        *authJWTAudience,
+			Required:        *authRequired,
+			AnonymousScopes: strings.Fields(*authAnonymousScopes),
+		}
+		authConfig.APIKeys, err = people.ParseAPIKeys(*authAPIKeys)
+		v.check("auth-api-keys", err)
+		if *authAPIKeysFile != "" {
+			keys, err := people.LoadAPIKeysFile(*authAPIKeysFile)
+			v.check("auth-api-keys-file", err)
+			authConfig.APIKeys = append(authConfig.APIKeys, keys...)
+		}
+		if *authJWKSFile != "" {
+			authConfig.JWKS, err = people.LoadJWKSFile(*authJWKSFile)
+			v.check("auth-jwks-file", err)
+		}
+		authEnabled := len(authConfig.APIKeys) > 0 || authConfig.JWKS != nil || authConfig.Required
+		for _, client := range rateLimitConfig.Clients {
+			if authEnabled && client.APIKey != "" {
+				v.check("rate-limit-clients", fmt.Errorf("client %s has an API key, clients are identified by their principal name as name:rate:burst when authentication is enabled", client.Name))
+			}
+			if !authEnabled && client.APIKey == "" {
+				v.check("rate-limit-clients", fmt.Errorf("client %s has no API key, which requires authentication", client.Name))
+			}
+		}
+		var redactionPolicy *people.RedactionPolicy
+		if *redactionPolicyFile != "" {
+			redactionPolicy, err = people.LoadRedactionPolicyFile(*redactionPolicyFile)
+			v.check("redaction-policy-file", err)
+			if redactionPolicy.HasClientRules() && !authEnabled {
+				v.fail("redaction-policy-file", *redactionPolicyFile, "no rules restricted to clients, as authentication is disabled")
+			}
+		}
+		renditions, err := people.ParseRenditionSpecs(*imageRenditions)
+		v.check("image-renditions", err)
+		if *canaryPersonUUID != "" && !people.IsValidUUID(*canaryPersonUUID) {
+			v.fail("canary-person-uuid", *canaryPersonUUID, "a UUID")
+		}
+		if err := v.err(); err != nil {
+			logger.Fatalf("Invalid configuration:\n%v", err)
+		}
+		if *printConfig {
+			os.Exit(0)
+		}
+
 		logger.Infof("System code: %s, App Name: %s, Port: %s", *appSystemCode, *appName, *port)
 
+		lifecycle := people.NewLifecycle(gracePeriod, drainTimeout)
+
 		appConfig := people.HealthConfig{
 			AppName:           *appName,
 			AppSystemCode:     *appSystemCode,
 			Description:       appDescription,
 			ReqLoggingEnabled: *requestLoggingEnabled,
+			CheckInterval:     checkInterval,
+			CheckStaleAfter:   checkStaleAfter,
+			Lifecycle:         lifecycle,
 		}
 
-		cacheDuration, durationErr := time.ParseDuration(*cacheDuration)
-		if durationErr != nil {
-			logger.Fatalf("Failed to parse cache duration string, %v", durationErr)
+		shutdownTracing, err := people.InitTracing(context.Background(), people.TracingConfig{
+			Exporter:    *tracingExporter,
+			Endpoint:    *tracingEndpoint,
+			ServiceName: *appSystemCode,
+			SampleRatio: sampleRatio,
+		})
+		if err != nil {
+			logger.Fatalf("Failed to initialise tracing, %v", err)
 		}
 
-		c := &http.Client{
-			Transport: &http.Transport{
+		httpClientConfig.log()
+		httpServerConfig.log()
 
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   10 * time.Second,
-					KeepAlive: 60 * time.Second,
-					DualStack: true,
-				}).DialContext,
-				MaxIdleConns:          20,
-				IdleConnTimeout:       60 * time.Second,
-				TLSHandshakeTimeout:   3 * time.Second,
-				ExpectContinueTimeout: 1 * time.Second,
-				MaxIdleConnsPerHost:   20,
-			},
+		imageService := people.ImageServiceConfig{
+			URLTemplate: *imageServiceURLTemplate,
+			Renditions:  renditions,
+		}
+
+		metrics := people.NewMetrics()
+		handler := people.NewHandler(cacheDuration, *publicConceptsApiURL, c,
+			people.WithImageService(imageService),
+			people.WithMetrics(metrics),
+			people.WithDebugToken(*debugToken),
+			people.WithRedactionPolicy(redactionPolicy),
+			people.WithRedirectPolicy(redirects),
+			people.WithConcordanceDepth(depth),
+			people.WithDeprecatedPolicy(deprecated),
+			people.WithDeprecatedSunset(sunset),
+			people.WithConceptSource(source),
+		)
+		if redactionPolicy.Len() > 0 {
+			logger.Infof("Redaction policy loaded with %d rules", redactionPolicy.Len())
+		}
+
+		checks := []v1_1.Check{handler.Healthchecks()}
+		if *canaryPersonUUID != "" {
+			canary := people.NewCanaryCheck(handler, *canaryPersonUUID, canaryInterval)
+			canary.Start(context.Background())
+			appConfig.ReportOnlyChecks = append(appConfig.ReportOnlyChecks, canary.Healthcheck())
 		}
-		handler := people.NewHandler(cacheDuration, *publicConceptsApiURL, c)
 
 		router := mux.NewRouter()
-		healthCheckService := people.NewHealthCheckService([]v1_1.Check{handler.Healthchecks()}, appConfig)
+		healthCheckService := people.NewHealthCheckService(checks, appConfig)
+		healthCheckService.Start(context.Background())
 
 		handler.RegisterHandlers(router)
-		r := healthCheckService.RegisterAdminHandlers(router)
+		if *adminPort == "" {
+			metrics.RegisterHandlers(router)
+		}
+		accessLogger := people.NewAccessLogger(accessLogRate)
+		rateLimiter := people.NewRateLimiter(rateLimitConfig, metrics)
+		api := rateLimiter.Handler(healthCheckService.RegisterAdminHandlers(router))
+		var grpcOptions []grpc.ServerOption
+		if authEnabled {
+			authenticator := people.NewAuthenticator(authConfig)
+			api = authenticator.Handler(api)
+			grpcOptions = append(grpcOptions,
+				grpc.UnaryInterceptor(authenticator.UnaryServerInterceptor()),
+				grpc.StreamInterceptor(authenticator.StreamServerInterceptor()),
+			)
+			logger.Infof("Authentication enabled with %d API keys, JWT: %t, required: %t", len(authConfig.APIKeys), authConfig.JWKS != nil, authConfig.Required)
+		}
+		if len(corsConfig.AllowedOrigins) > 0 {
+			api = people.NewCORS(corsConfig).Handler(api)
+			logger.Infof("CORS enabled for origins %s", strings.Join(corsConfig.AllowedOrigins, ", "))
+		}
+		r := metrics.Instrument(router, accessLogger.Handler(api))
+		if len(compressionConfig.Encodings) > 0 {
+			r = people.NewCompressor(compressionConfig).Handler(r)
+			logger.Infof("Compression enabled with %s for responses of at least %d bytes", strings.Join(compressionConfig.Encodings, ", "), compressionConfig.MinSize)
+		}
 
 		httpServer := &http.Server{
-			Addr:         fmt.Sprintf("0.0.0.0:%s", *port),
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
+			Addr:    fmt.Sprintf("0.0.0.0:%s", *port),
+			Handler: r,
 		}
-		httpServer.Handler = r
+		httpServerConfig.apply(httpServer)
 
-		sig := make(chan os.Signal)
+		var adminServer *http.Server
+		if *adminPort != "" {
+			adminRouter := mux.NewRouter()
+			healthCheckService.RegisterHealthHandlers(adminRouter)
+			metrics.RegisterHandlers(adminRouter)
+			handler.RegisterDebugHandlers(adminRouter)
+			people.RegisterDiagnosticsHandlers(adminRouter)
+			adminServer = &http.Server{
+				Addr:              fmt.Sprintf("0.0.0.0:%s", *adminPort),
+				Handler:           adminRouter,
+				ReadHeaderTimeout: httpServerConfig.ReadHeaderTimeout,
+			}
+		}
+
+		grpcServer := grpc.NewServer(grpcOptions...)
+		people.NewGRPCServer(handler).Register(grpcServer)
+		healthCheckService.RegisterGRPCHealthServer(grpcServer)
+
+		sig := make(chan os.Signal, 1)
 		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 
 		go func() {
@@ -128,19 +718,57 @@ func main() {
 			sig <- os.Interrupt
 		}()
 
-		<-sig
-		logger.Infof("Caught SIG: %#v", sig)
-		logger.Infof("Wait for 5 seconds to finish processing")
-
-		logger.Info("Shutting down HTTP server...")
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-		if err := httpServer.Shutdown(ctx); err != nil {
-			logger.Info("HTTP server could not be properly shut down")
+		if adminServer != nil {
+			go func() {
+				logger.Infof("Admin listening on %s", adminServer.Addr)
+				if err := adminServer.ListenAndServe(); err != nil {
+					logger.Errorf("Admin server got shut down, error: %v", err)
+				}
+				sig <- os.Interrupt
+			}()
 		}
-		logger.Info("HTTP server shut down")
 
-		time.Sleep(5 * time.Second)
+		go func() {
+			grpcAddr := fmt.Sprintf("0.0.0.0:%s", *grpcPort)
+			lis, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				logger.Errorf("gRPC server could not listen on %s, error: %v", grpcAddr, err)
+				sig <- os.Interrupt
+				return
+			}
+			logger.Infof("gRPC listening on %s", grpcAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Errorf("gRPC server got shut down, error: %v", err)
+			}
+			sig <- os.Interrupt
+		}()
+
+		received := <-sig
+		logger.Infof("Caught SIG: %v", received)
+
+		err = lifecycle.Shutdown(
+			func(ctx context.Context) error {
+				logger.Info("Shutting down HTTP server...")
+				return httpServer.Shutdown(ctx)
+			},
+			func(ctx context.Context) error {
+				logger.Info("Shutting down gRPC server...")
+				return people.GracefulStopGRPC(grpcServer)(ctx)
+			},
+			func(ctx context.Context) error {
+				if adminServer == nil {
+					return nil
+				}
+				logger.Info("Shutting down admin server...")
+				return adminServer.Shutdown(ctx)
+			},
+			shutdownTracing,
+		)
+		if err != nil {
+			logger.Errorf("Service could not be properly shut down, error: %v", err)
+			os.Exit(1)
+		}
+		logger.Info("Service shut down")
 		os.Exit(0)
 	}
 
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+)
+
+// clientConfig configures the HTTP client used to call public-concepts-api
+type clientConfig struct {
+	Timeout               time.Duration
+	DialTimeout           time.Duration
+	KeepAlive             time.Duration
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	IdleConnTimeout       time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	ExpectContinueTimeout time.Duration
+	// CABundle is a PEM file of CAs trusted in addition to the system roots
+	CABundle string
+	// ClientCert and ClientKey are PEM files of the certificate presented for mTLS
+	ClientCert string
+	ClientKey  string
+}
+
+func newHTTPClient(config clientConfig) (*http.Client, error) {
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   config.DialTimeout,
+				KeepAlive: config.KeepAlive,
+			}).DialContext,
+			MaxIdleConns:          config.MaxIdleConns,
+			MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+			IdleConnTimeout:       config.IdleConnTimeout,
+			TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+			ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+			ExpectContinueTimeout: config.ExpectContinueTimeout,
+			TLSClientConfig:       tlsConfig,
+		},
+	}, nil
+}
+
+// tlsConfig returns nil, and so the default TLS settings, unless a CA bundle or client certificate is configured
+func (c clientConfig) tlsConfig() (*tls.Config, error) {
+	if c.CABundle == "" && c.ClientCert == "" && c.ClientKey == "" {
+		return nil, nil
+	}
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return nil, errors.New("client certificate and key must be configured together")
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CABundle != "" {
+		pem, err := os.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", c.CABundle)
+		}
+		config.RootCAs = pool
+	}
+	if c.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func (c clientConfig) log() {
+	logger.WithFields(map[string]interface{}{
+		"timeout":                 c.Timeout.String(),
+		"dial_timeout":            c.DialTimeout.String(),
+		"keep_alive":              c.KeepAlive.String(),
+		"max_idle_conns":          c.MaxIdleConns,
+		"max_idle_conns_per_host": c.MaxIdleConnsPerHost,
+		"idle_conn_timeout":       c.IdleConnTimeout.String(),
+		"tls_handshake_timeout":   c.TLSHandshakeTimeout.String(),
+		"response_header_timeout": c.ResponseHeaderTimeout.String(),
+		"expect_continue_timeout": c.ExpectContinueTimeout.String(),
+		"ca_bundle":               c.CABundle,
+		"client_cert":             c.ClientCert,
+	}).Info("public-concepts-api client settings")
+}
+
+// serverConfig configures the timeouts of the public HTTP server
+type serverConfig struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+func (c serverConfig) apply(server *http.Server) {
+	server.ReadTimeout = c.ReadTimeout
+	server.ReadHeaderTimeout = c.ReadHeaderTimeout
+	server.WriteTimeout = c.WriteTimeout
+	server.IdleTimeout = c.IdleTimeout
+}
+
+func (c serverConfig) log() {
+	logger.WithFields(map[string]interface{}{
+		"read_timeout":        c.ReadTimeout.String(),
+		"read_header_timeout": c.ReadHeaderTimeout.String(),
+		"write_timeout":       c.WriteTimeout.String(),
+		"idle_timeout":        c.IdleTimeout.String(),
+	}).Info("HTTP server settings")
+}
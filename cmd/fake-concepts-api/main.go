@@ -0,0 +1,76 @@
+// fake-concepts-api serves concepts from a directory of fixtures like public-concepts-api, so that
+// public-people-api can run offline with --publicConceptsApiURL pointing at it.
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/conceptstest"
+	cli "github.com/jawher/mow.cli"
+)
+
+func main() {
+	app := cli.App("fake-concepts-api", "A fake public-concepts-api serving concepts from fixtures")
+	port := app.String(cli.StringOpt{
+		Name:   "port",
+		Value:  "8080",
+		Desc:   "Port to listen on",
+		EnvVar: "PORT",
+	})
+	fixturesDir := app.String(cli.StringOpt{
+		Name:   "fixtures",
+		Value:  "conceptstest/testdata",
+		Desc:   "Directory of concepts, one <uuid>.json file each, and their concordances in concordances.json",
+		EnvVar: "FIXTURES",
+	})
+	latency := app.String(cli.StringOpt{
+		Name:   "latency",
+		Value:  "0s",
+		Desc:   "Delay of every response",
+		EnvVar: "LATENCY",
+	})
+	errorRate := app.String(cli.StringOpt{
+		Name:   "error-rate",
+		Value:  "0",
+		Desc:   "Fraction of concept requests, between 0 and 1, answered with --error-status",
+		EnvVar: "ERROR_RATE",
+	})
+	errorStatus := app.Int(cli.IntOpt{
+		Name:   "error-status",
+		Value:  http.StatusServiceUnavailable,
+		Desc:   "Status of the failed concept requests",
+		EnvVar: "ERROR_STATUS",
+	})
+
+	app.Action = func() {
+		logger.InitDefaultLogger("fake-concepts-api")
+
+		delay, err := time.ParseDuration(*latency)
+		if err != nil {
+			logger.Fatalf("Invalid latency %q, %v", *latency, err)
+		}
+		rate, err := strconv.ParseFloat(*errorRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			logger.Fatalf("Invalid error rate %q, expected a number between 0 and 1", *errorRate)
+		}
+		fixtures, err := conceptstest.LoadFixtures(*fixturesDir)
+		if err != nil {
+			logger.Fatalf("Invalid fixtures in %s, %v", *fixturesDir, err)
+		}
+
+		api := conceptstest.NewAPI(fixtures, conceptstest.WithLatency(delay), conceptstest.WithErrorRate(rate, *errorStatus))
+		logger.Infof("Serving %d concepts and %d concordances from %s on port %s",
+			len(fixtures.Concepts), len(fixtures.Concordances), *fixturesDir, *port)
+		if err := http.ListenAndServe(":"+*port, api); err != nil {
+			logger.Fatalf("Unable to start server, %v", err)
+		}
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		logger.Errorf("App could not start, error=[%s]\n", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package conceptstest is a fake public-concepts-api, serving concepts from fixtures, for running and testing the
+// service offline.
+package conceptstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ConcordancesFile is the fixture of concorded UUIDs, a JSON object of each to its canonical UUID
+	ConcordancesFile = "concordances.json"
+
+	contentTypeJSON = "application/json"
+)
+
+var uuidRegexp = regexp.MustCompile("^[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$")
+
+// Fixtures are the concepts served, by UUID, and the UUIDs concorded to a canonical one, which are redirected to it
+type Fixtures struct {
+	Concepts     map[string]json.RawMessage
+	Concordances map[string]string
+}
+
+// LoadFixtures reads a directory of concepts, one <uuid>.json file each, and its concordances in ConcordancesFile
+func LoadFixtures(dir string) (Fixtures, error) {
+	fixtures := Fixtures{Concepts: map[string]json.RawMessage{}, Concordances: map[string]string{}}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fixtures, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fixtures, err
+		}
+		name := filepath.Base(file)
+		if name == ConcordancesFile {
+			if err := json.Unmarshal(data, &fixtures.Concordances); err != nil {
+				return fixtures, fmt.Errorf("%s: %w", name, err)
+			}
+			continue
+		}
+		uuid := strings.TrimSuffix(name, ".json")
+		if !uuidRegexp.MatchString(uuid) {
+			return fixtures, fmt.Errorf("%s: expected a file named after the UUID of its concept", name)
+		}
+		if !json.Valid(data) {
+			return fixtures, fmt.Errorf("%s: invalid JSON", name)
+		}
+		fixtures.Concepts[uuid] = data
+	}
+	for uuid, canonical := range fixtures.Concordances {
+		if !uuidRegexp.MatchString(uuid) || !uuidRegexp.MatchString(canonical) {
+			return fixtures, fmt.Errorf("%s: invalid concordance of %q to %q", ConcordancesFile, uuid, canonical)
+		}
+	}
+	return fixtures, nil
+}
+
+// API serves GET /concepts/{uuid} and GET /__gtg like public-concepts-api. Its fixtures, latency and failures can be
+// changed while it serves.
+type API struct {
+	router *mux.Router
+	random func() float64
+
+	mu          sync.RWMutex
+	fixtures    Fixtures
+	latency     time.Duration
+	errorRate   float64
+	errorStatus int
+	failures    map[string]int
+	unhealthy   bool
+	requests    map[string]int
+}
+
+// Option configures an API
+type Option func(*API)
+
+// WithLatency delays every response
+func WithLatency(latency time.Duration) Option {
+	return func(a *API) {
+		a.latency = latency
+	}
+}
+
+// WithErrorRate answers the given fraction of concept requests, between 0 and 1, with the status
+func WithErrorRate(rate float64, status int) Option {
+	return func(a *API) {
+		a.errorRate = rate
+		a.errorStatus = status
+	}
+}
+
+func NewAPI(fixtures Fixtures, opts ...Option) *API {
+	if fixtures.Concepts == nil {
+		fixtures.Concepts = map[string]json.RawMessage{}
+	}
+	if fixtures.Concordances == nil {
+		fixtures.Concordances = map[string]string{}
+	}
+	a := &API{
+		router:      mux.NewRouter(),
+		random:      rand.Float64,
+		fixtures:    fixtures,
+		errorStatus: http.StatusServiceUnavailable,
+		failures:    map[string]int{},
+		requests:    map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.router.HandleFunc("/concepts/{uuid}", a.concept).Methods("GET")
+	a.router.HandleFunc("/__gtg", a.gtg).Methods("GET")
+	return a
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.router.ServeHTTP(w, r)
+}
+
+// Put serves the concept for the UUID
+func (a *API) Put(uuid string, concept json.RawMessage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fixtures.Concepts[uuid] = concept
+}
+
+// Concord redirects requests for the UUID to the canonical UUID
+func (a *API) Concord(uuid, canonical string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fixtures.Concordances[uuid] = canonical
+}
+
+// Fail answers requests for the UUID with the status, or as usual again when the status is 0
+func (a *API) Fail(uuid string, status int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if status == 0 {
+		delete(a.failures, uuid)
+		return
+	}
+	a.failures[uuid] = status
+}
+
+// SetLatency delays every response
+func (a *API) SetLatency(latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.latency = latency
+}
+
+// SetHealthy sets whether /__gtg answers good to go
+func (a *API) SetHealthy(healthy bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.unhealthy = !healthy
+}
+
+// Requests is the number of requests for the UUID so far
+func (a *API) Requests(uuid string) int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.requests[uuid]
+}
+
+func (a *API) concept(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	a.mu.Lock()
+	a.requests[uuid]++
+	latency := a.latency
+	status, failing := a.failures[uuid]
+	if !failing && a.errorRate > 0 && a.random() < a.errorRate {
+		status, failing = a.errorStatus, true
+	}
+	canonical, concorded := a.fixtures.Concordances[uuid]
+	concept, found := a.fixtures.Concepts[uuid]
+	a.mu.Unlock()
+
+	if !a.delay(r, latency) {
+		return
+	}
+	switch {
+	case failing:
+		writeMessage(w, "Injected failure", status)
+	case concorded:
+		location := *r.URL
+		location.Path = "/concepts/" + canonical
+		http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+	case found:
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.Write(concept)
+	default:
+		writeMessage(w, "Concept not found", http.StatusNotFound)
+	}
+}
+
+func (a *API) gtg(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	latency, unhealthy := a.latency, a.unhealthy
+	a.mu.RUnlock()
+
+	if !a.delay(r, latency) {
+		return
+	}
+	if unhealthy {
+		http.Error(w, "Not good to go", http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("OK"))
+}
+
+// delay waits for the latency, and reports whether the request is still wanted
+func (a *API) delay(r *http.Request, latency time.Duration) bool {
+	if latency <= 0 {
+		return true
+	}
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func writeMessage(w http.ResponseWriter, message string, status int) {
+	body, _ := json.Marshal(map[string]string{"message": message})
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Server is an API listening on a local address, for tests
+type Server struct {
+	*API
+	*httptest.Server
+}
+
+// NewServer starts an API serving the fixtures. Close the server when done.
+func NewServer(fixtures Fixtures, opts ...Option) *Server {
+	api := NewAPI(fixtures, opts...)
+	return &Server{API: api, Server: httptest.NewServer(api)}
+}
@@ -0,0 +1,168 @@
+package conceptstest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/conceptstest"
+	"github.com/Financial-Times/public-people-api/v3/people"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	personUUID     = "60e54253-1e94-38df-83b1-a39804d1ac18"
+	concordedUUID  = "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	deprecatedUUID = "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"
+)
+
+func newTestServer(t *testing.T, opts ...conceptstest.Option) *conceptstest.Server {
+	fixtures, err := conceptstest.LoadFixtures("testdata")
+	require.NoError(t, err)
+	server := conceptstest.NewServer(fixtures, opts...)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := conceptstest.LoadFixtures("testdata")
+	require.NoError(t, err)
+	assert.Len(t, fixtures.Concepts, 2)
+	assert.Equal(t, map[string]string{concordedUUID: personUUID}, fixtures.Concordances)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "someone.json"), []byte(`{}`), 0o644))
+	_, err = conceptstest.LoadFixtures(dir)
+	assert.EqualError(t, err, "someone.json: expected a file named after the UUID of its concept")
+
+	dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, personUUID+".json"), []byte(`{`), 0o644))
+	_, err = conceptstest.LoadFixtures(dir)
+	assert.EqualError(t, err, personUUID+".json: invalid JSON")
+}
+
+func TestAPI_Concepts(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server.URL+"/concepts/"+personUUID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var concept map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &concept))
+	assert.Equal(t, "http://www.ft.com/thing/"+personUUID, concept["id"])
+
+	resp, _ = get(t, server.URL+"/concepts/"+concordedUUID+"?showRelationship=related")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/concepts/"+personUUID, resp.Request.URL.Path, "concorded UUIDs redirect to the canonical concept")
+	assert.Equal(t, "showRelationship=related", resp.Request.URL.RawQuery)
+
+	resp, body = get(t, server.URL+"/concepts/2d3e16e0-61cb-4322-8aff-3b01c59f4daa")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, `{"message":"Concept not found"}`, body)
+
+	server.Put("2d3e16e0-61cb-4322-8aff-3b01c59f4daa", json.RawMessage(`{"id":"http://www.ft.com/thing/2d3e16e0-61cb-4322-8aff-3b01c59f4daa"}`))
+	resp, _ = get(t, server.URL+"/concepts/2d3e16e0-61cb-4322-8aff-3b01c59f4daa")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, server.Requests(personUUID))
+	assert.Equal(t, 1, server.Requests(concordedUUID))
+}
+
+func TestAPI_Failures(t *testing.T) {
+	server := newTestServer(t)
+
+	server.Fail(personUUID, http.StatusInternalServerError)
+	resp, body := get(t, server.URL+"/concepts/"+personUUID)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, `{"message":"Injected failure"}`, body)
+
+	server.Fail(personUUID, 0)
+	resp, _ = get(t, server.URL+"/concepts/"+personUUID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	failing := newTestServer(t, conceptstest.WithErrorRate(1, http.StatusBadGateway))
+	resp, _ = get(t, failing.URL+"/concepts/"+personUUID)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestAPI_GTG(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, body := get(t, server.URL+"/__gtg")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "OK", body)
+
+	server.SetHealthy(false)
+	resp, _ = get(t, server.URL+"/__gtg")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestAPI_Latency(t *testing.T) {
+	server := newTestServer(t, conceptstest.WithLatency(50*time.Millisecond))
+
+	start := time.Now()
+	resp, _ := get(t, server.URL+"/concepts/"+personUUID)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/concepts/"+personUUID, nil)
+	require.NoError(t, err)
+	_, err = http.DefaultClient.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPeopleAPI(t *testing.T) {
+	logger.InitDefaultLogger("conceptstest")
+	server := newTestServer(t)
+	router := mux.NewRouter()
+	handler := people.NewHandler(0, server.URL, server.Client())
+	handler.RegisterHandlers(router)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	rec := serve("/people/" + personUUID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var person people.Person
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&person))
+	assert.Equal(t, "Neil Cole", person.PrefLabel)
+
+	rec = serve("/people/" + concordedUUID)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/people/"+personUUID, rec.Header().Get("Location"))
+
+	rec = serve("/people/" + deprecatedUUID + "?deprecated=successor")
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/people/"+personUUID+"?deprecated=successor", rec.Header().Get("Location"))
+
+	server.Fail(personUUID, http.StatusNotFound)
+	assert.Equal(t, http.StatusNotFound, serve("/people/"+personUUID).Code)
+
+	_, err := handler.Checker()
+	assert.NoError(t, err)
+	server.SetHealthy(false)
+	_, err = handler.Checker()
+	assert.Error(t, err)
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cli "github.com/jawher/mow.cli"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "REDACTED"
+
+// option is a command line option that can also be set from the config file
+type option struct {
+	name      string
+	envVar    string
+	secret    bool
+	setByUser bool
+	get       func() string
+	set       func(string) error
+}
+
+// options registers command line options and merges them with a YAML or JSON config file.
+// Flags take precedence over environment variables, which take precedence over the config file.
+type options struct {
+	app  *cli.Cli
+	list []*option
+}
+
+func newOptions(app *cli.Cli) *options {
+	return &options{app: app}
+}
+
+func (o *options) String(opt cli.StringOpt) *string {
+	entry := &option{name: opt.Name, envVar: opt.EnvVar}
+	opt.SetByUser = &entry.setByUser
+	value := o.app.String(opt)
+	entry.get = func() string { return *value }
+	entry.set = func(s string) error { *value = s; return nil }
+	o.list = append(o.list, entry)
+	return value
+}
+
+// Secret registers an option whose value is hidden from the help text and redacted when the config is printed
+func (o *options) Secret(opt cli.StringOpt) *string {
+	opt.HideValue = true
+	value := o.String(opt)
+	o.list[len(o.list)-1].secret = true
+	return value
+}
+
+func (o *options) Bool(opt cli.BoolOpt) *bool {
+	entry := &option{name: opt.Name, envVar: opt.EnvVar}
+	opt.SetByUser = &entry.setByUser
+	value := o.app.Bool(opt)
+	entry.get = func() string { return strconv.FormatBool(*value) }
+	entry.set = func(s string) error {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", s)
+		}
+		*value = b
+		return nil
+	}
+	o.list = append(o.list, entry)
+	return value
+}
+
+// LoadFile reads option values keyed by option name from a YAML or JSON file, for every option that is not set
+// by a flag or an environment variable
+func (o *options) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	known := map[string]*option{}
+	for _, entry := range o.list {
+		known[entry.name] = entry
+	}
+	var errs []error
+	for name, value := range values {
+		entry, ok := known[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown option %q in config file %s", name, path))
+			continue
+		}
+		if entry.setByUser || entry.setFromEnv() {
+			continue
+		}
+		s, ok := configValue(value)
+		if !ok {
+			errs = append(errs, fmt.Errorf("option %q in config file %s must be a single value or a list of values", name, path))
+			continue
+		}
+		if err := entry.set(s); err != nil {
+			errs = append(errs, fmt.Errorf("option %q in config file %s: %w", name, path, err))
+		}
+	}
+	sortErrors(errs)
+	return errors.Join(errs...)
+}
+
+// configValue formats a config file value as it would be given on the command line, with the items of a list
+// separated by commas
+func configValue(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case nil, map[string]interface{}:
+		return "", false
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			switch item.(type) {
+			case nil, map[string]interface{}, []interface{}:
+				return "", false
+			}
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ","), true
+	default:
+		return fmt.Sprint(value), true
+	}
+}
+
+func (e *option) setFromEnv() bool {
+	if e.envVar == "" {
+		return false
+	}
+	_, ok := os.LookupEnv(e.envVar)
+	return ok
+}
+
+// Print writes the effective option values as YAML, which can be used as a config file, with secrets redacted
+func (o *options) Print(w io.Writer) error {
+	values := map[string]string{}
+	for _, entry := range o.list {
+		value := entry.get()
+		if entry.secret && value != "" {
+			value = redacted
+		}
+		values[entry.name] = value
+	}
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(values)
+}
+
+// validator parses option values and collects every invalid value, so all of them can be reported at once
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(name, value, expected string) {
+	v.errs = append(v.errs, fmt.Errorf("invalid %s %q, expected %s", name, value, expected))
+}
+
+func (v *validator) check(name string, err error) {
+	if err != nil {
+		v.errs = append(v.errs, fmt.Errorf("invalid %s: %w", name, err))
+	}
+}
+
+// duration parses a duration of at least min
+func (v *validator) duration(name, value string, min time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < min {
+		if min > 0 {
+			v.fail(name, value, "a duration of at least "+min.String())
+		} else {
+			v.fail(name, value, "a duration such as 30s or 1m, not negative")
+		}
+	}
+	return d
+}
+
+// fraction parses a number between 0 and 1
+func (v *validator) fraction(name, value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || f > 1 {
+		v.fail(name, value, "a number between 0 and 1")
+	}
+	return f
+}
+
+// nonNegative parses a number of at least 0
+func (v *validator) nonNegative(name, value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		v.fail(name, value, "a number of at least 0")
+	}
+	return f
+}
+
+// positiveInt parses a whole number greater than 0
+func (v *validator) positiveInt(name, value string) int {
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		v.fail(name, value, "a whole number greater than 0")
+	}
+	return i
+}
+
+// port checks a TCP port number. Empty is accepted when optional.
+func (v *validator) port(name, value string, optional bool) {
+	if value == "" && optional {
+		return
+	}
+	p, err := strconv.Atoi(value)
+	if err != nil || p < 1 || p > 65535 {
+		v.fail(name, value, "a port number between 1 and 65535")
+	}
+}
+
+// url checks an absolute http or https URL. Empty is accepted when optional.
+func (v *validator) url(name, value string, optional bool) {
+	if value == "" && optional {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fail(name, value, "an absolute http or https URL")
+	}
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.fail(name, value, fmt.Sprintf("one of %v", allowed))
+}
+
+func (v *validator) err() error {
+	return errors.Join(v.errs...)
+}
+
+func sortErrors(errs []error) {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOptions struct {
+	opts    *options
+	port    *string
+	cache   *string
+	logging *bool
+	token   *string
+	origins *string
+}
+
+func newTestOptions() (*cli.Cli, *testOptions) {
+	app := cli.App("test", "")
+	opts := newOptions(app)
+	return app, &testOptions{
+		opts:    opts,
+		port:    opts.String(cli.StringOpt{Name: "port", Value: "8080", EnvVar: "TEST_CONFIG_PORT"}),
+		cache:   opts.String(cli.StringOpt{Name: "cache-duration", Value: "30s", EnvVar: "TEST_CONFIG_CACHE_DURATION"}),
+		logging: opts.Bool(cli.BoolOpt{Name: "requestLoggingEnabled", Value: true, EnvVar: "TEST_CONFIG_LOGGING"}),
+		token:   opts.Secret(cli.StringOpt{Name: "debug-token", Value: "", EnvVar: "TEST_CONFIG_TOKEN"}),
+		origins: opts.String(cli.StringOpt{Name: "cors-allowed-origins", Value: "", EnvVar: "TEST_CONFIG_ORIGINS"}),
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestOptions_LoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "config.yaml", "port: 9000\ncache-duration: 2m\nrequestLoggingEnabled: false\ndebug-token: secret\ncors-allowed-origins:\n  - https://a.ft.com\n  - https://b.ft.com\n"},
+		{"json", "config.json", `{"port": 9000, "cache-duration": "2m", "requestLoggingEnabled": false, "debug-token": "secret", "cors-allowed-origins": ["https://a.ft.com", "https://b.ft.com"]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, o := newTestOptions()
+			path := writeConfigFile(t, test.file, test.content)
+			app.Action = func() {
+				require.NoError(t, o.opts.LoadFile(path))
+			}
+			require.NoError(t, app.Run([]string{"test"}))
+
+			assert.Equal(t, "9000", *o.port)
+			assert.Equal(t, "2m", *o.cache)
+			assert.False(t, *o.logging)
+			assert.Equal(t, "secret", *o.token)
+			assert.Equal(t, "https://a.ft.com,https://b.ft.com", *o.origins, "lists should be comma separated")
+		})
+	}
+}
+
+func TestOptions_LoadFilePrecedence(t *testing.T) {
+	t.Setenv("TEST_CONFIG_CACHE_DURATION", "5m")
+	app, o := newTestOptions()
+	path := writeConfigFile(t, "config.yaml", "port: 9000\ncache-duration: 2m\nrequestLoggingEnabled: false\n")
+	app.Action = func() {
+		require.NoError(t, o.opts.LoadFile(path))
+	}
+	require.NoError(t, app.Run([]string{"test", "--port", "7000"}))
+
+	assert.Equal(t, "7000", *o.port, "flags should take precedence over the config file")
+	assert.Equal(t, "5m", *o.cache, "environment variables should take precedence over the config file")
+	assert.False(t, *o.logging)
+}
+
+func TestOptions_LoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"unknown option", "prot: 9000\n", `unknown option "prot"`},
+		{"nested value", "port:\n  http: 9000\n", `option "port" in config file`},
+		{"nested list", "cors-allowed-origins:\n  - [https://a.ft.com]\n", `option "cors-allowed-origins" in config file`},
+		{"invalid bool", "requestLoggingEnabled: sometimes\n", `expected true or false, got "sometimes"`},
+		{"invalid syntax", "port: [9000\n", "could not parse config file"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, o := newTestOptions()
+			err := o.opts.LoadFile(writeConfigFile(t, "config.yaml", test.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+
+	_, o := newTestOptions()
+	assert.ErrorContains(t, o.opts.LoadFile(filepath.Join(t.TempDir(), "missing.yaml")), "could not read config file")
+}
+
+func TestOptions_PrintRedactsSecrets(t *testing.T) {
+	app, o := newTestOptions()
+	var out bytes.Buffer
+	app.Action = func() {
+		require.NoError(t, o.opts.Print(&out))
+	}
+	require.NoError(t, app.Run([]string{"test", "--debug-token", "secret"}))
+
+	assert.Equal(t, "cache-duration: 30s\ncors-allowed-origins: \"\"\ndebug-token: REDACTED\nport: \"8080\"\nrequestLoggingEnabled: \"true\"\n", out.String())
+	assert.NotContains(t, out.String(), "secret")
+}
+
+func TestValidator(t *testing.T) {
+	v := &validator{}
+	assert.Equal(t, 30*time.Second, v.duration("cache-duration", "30s", 0))
+	assert.Equal(t, 0.5, v.fraction("tracing-sample-ratio", "0.5"))
+	assert.Equal(t, 2.5, v.nonNegative("rate-limit", "2.5"))
+	assert.Equal(t, 20, v.positiveInt("rate-limit-burst", "20"))
+	v.port("port", "8080", false)
+	v.port("admin-port", "", true)
+	v.url("publicConceptsApiURL", "http://localhost:8080", false)
+	v.oneOf("tracing-exporter", "otlp", "none", "otlp")
+	assert.NoError(t, v.err())
+
+	v.duration("cache-duration", "-1s", 0)
+	v.duration("shutdown-timeout", "0s", time.Millisecond)
+	v.fraction("tracing-sample-ratio", "2")
+	v.nonNegative("rate-limit", "-1")
+	v.positiveInt("rate-limit-burst", "0")
+	v.port("port", "", false)
+	v.port("grpc-port", "70000", false)
+	v.url("publicConceptsApiURL", "localhost:8080", false)
+	v.oneOf("tracing-exporter", "jaeger", "none", "otlp")
+
+	err := v.err()
+	require.Error(t, err)
+	assert.Equal(t, `invalid cache-duration "-1s", expected a duration such as 30s or 1m, not negative
+invalid shutdown-timeout "0s", expected a duration of at least 1ms
+invalid tracing-sample-ratio "2", expected a number between 0 and 1
+invalid rate-limit "-1", expected a number of at least 0
+invalid rate-limit-burst "0", expected a whole number greater than 0
+invalid port "", expected a port number between 1 and 65535
+invalid grpc-port "70000", expected a port number between 1 and 65535
+invalid publicConceptsApiURL "localhost:8080", expected an absolute http or https URL
+invalid tracing-exporter "jaeger", expected one of [none otlp]`, err.Error())
+}
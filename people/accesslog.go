@@ -0,0 +1,135 @@
+package people
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+)
+
+const accessLogEvent = "access"
+
+type accessRecordKey struct{}
+
+// accessRecord collects what happened while serving a request, for the access log entry written once the request completes
+type accessRecord struct {
+	mu               sync.Mutex
+	uuid             string
+	canonicalUUID    string
+	outcome          string
+	upstreamCalls    int
+	upstreamStatus   int
+	upstreamDuration time.Duration
+}
+
+func withAccessRecord(ctx context.Context, rec *accessRecord) context.Context {
+	return context.WithValue(ctx, accessRecordKey{}, rec)
+}
+
+// accessRecordFromContext returns the record of the current request, or nil when access logging is disabled
+func accessRecordFromContext(ctx context.Context) *accessRecord {
+	rec, _ := ctx.Value(accessRecordKey{}).(*accessRecord)
+	return rec
+}
+
+func (a *accessRecord) setPerson(uuid, canonicalUUID, outcome string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.uuid, a.canonicalUUID, a.outcome = uuid, canonicalUUID, outcome
+}
+
+// addUpstream records a call to public-concepts-api. Following a concordance chain makes one call per hop, so the
+// calls are counted and their durations summed, with the status of the last call.
+func (a *accessRecord) addUpstream(status int, d time.Duration) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.upstreamCalls++
+	a.upstreamStatus = status
+	a.upstreamDuration += d
+}
+
+// AccessLogger writes one structured log entry per request, for a sampled fraction of requests.
+// It is independent of the request logging enabled by HealthConfig.ReqLoggingEnabled.
+type AccessLogger struct {
+	sampleRate float64
+	random     func() float64
+}
+
+// NewAccessLogger logs the given fraction of requests, between 0 (none) and 1 (all)
+func NewAccessLogger(sampleRate float64) *AccessLogger {
+	return &AccessLogger{
+		sampleRate: sampleRate,
+		random:     rand.Float64,
+	}
+}
+
+func (l *AccessLogger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.sampleRate <= 0 || l.random() >= l.sampleRate {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		record := &accessRecord{}
+		rw := &sizeRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+		start := time.Now()
+		next.ServeHTTP(rw, r.WithContext(withAccessRecord(r.Context(), record)))
+
+		record.mu.Lock()
+		defer record.mu.Unlock()
+		fields := map[string]interface{}{
+			"event":         accessLogEvent,
+			"method":        r.Method,
+			"path":          r.URL.Path,
+			"status":        rw.status,
+			"duration_ms":   durationMillis(time.Since(start)),
+			"response_size": rw.size,
+		}
+		if record.outcome != "" {
+			fields["uuid"] = record.uuid
+			fields["outcome"] = record.outcome
+		}
+		if cacheControl := rw.Header().Get("Cache-Control"); cacheControl != "" {
+			fields["cache_control"] = cacheControl
+		}
+		if record.canonicalUUID != "" && record.canonicalUUID != record.uuid {
+			fields["canonical_uuid"] = record.canonicalUUID
+		}
+		if record.upstreamCalls > 0 {
+			fields["upstream_calls"] = record.upstreamCalls
+			fields["upstream_status"] = record.upstreamStatus
+			fields["upstream_duration_ms"] = durationMillis(record.upstreamDuration)
+		}
+		tid := rw.Header().Get(transactionidutils.TransactionIDHeader)
+		if tid == "" {
+			tid = r.Header.Get(transactionidutils.TransactionIDHeader)
+		}
+		logger.WithFields(fields).WithTransactionID(tid).Info("Request served")
+	})
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// sizeRecorder captures the status code and the number of body bytes written by a handler
+type sizeRecorder struct {
+	statusRecorder
+	size int
+}
+
+func (r *sizeRecorder) Write(b []byte) (int, error) {
+	n, err := r.statusRecorder.Write(b)
+	r.size += n
+	return n, err
+}
@@ -0,0 +1,85 @@
+package people
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestAccessLogger(t *testing.T) {
+	logger.InitDefaultLogger("access-log-test")
+	var out bytes.Buffer
+	logger.Logger().Out = &out
+	defer func() { logger.Logger().Out = os.Stderr }()
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid, canonicalUUID := registerConcordedPerson()
+
+	handler := NewAccessLogger(1).Handler(newTestRouter(time.Minute))
+
+	req := newRequest("GET", "/people/"+uuid, "")
+	req.Header.Set("X-Request-Id", "tid_access")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	entry := lastAccessLogEntry(t, out.Bytes())
+	assert.Equal(t, "tid_access", entry["transaction_id"])
+	assert.Equal(t, uuid, entry["uuid"])
+	assert.Equal(t, canonicalUUID, entry["canonical_uuid"])
+	assert.Equal(t, outcomeRedirect, entry["outcome"])
+	assert.Equal(t, float64(http.StatusMovedPermanently), entry["status"])
+	assert.Equal(t, float64(2), entry["upstream_calls"], "both hops of the concordance should be counted")
+	assert.Equal(t, float64(http.StatusOK), entry["upstream_status"])
+	assert.Contains(t, entry, "upstream_duration_ms")
+	assert.Equal(t, float64(rec.Body.Len()), entry["response_size"])
+
+	handler.ServeHTTP(httptest.NewRecorder(), newRequest("GET", "/people/"+canonicalUUID, ""))
+	entry = lastAccessLogEntry(t, out.Bytes())
+	assert.Equal(t, outcomeOK, entry["outcome"])
+	assert.Equal(t, float64(1), entry["upstream_calls"])
+	assert.Equal(t, "max-age=60, public", entry["cache_control"])
+}
+
+func TestAccessLogger_Sampling(t *testing.T) {
+	logger.InitDefaultLogger("access-log-test")
+	var out bytes.Buffer
+	logger.Logger().Out = &out
+	defer func() { logger.Logger().Out = os.Stderr }()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	l := NewAccessLogger(0.5)
+
+	l.random = func() float64 { return 0.7 }
+	l.Handler(next).ServeHTTP(httptest.NewRecorder(), newRequest("GET", "/__gtg", ""))
+	assert.Empty(t, out.String())
+
+	l.random = func() float64 { return 0.2 }
+	l.Handler(next).ServeHTTP(httptest.NewRecorder(), newRequest("GET", "/__gtg", ""))
+	entry := lastAccessLogEntry(t, out.Bytes())
+	assert.Equal(t, "/__gtg", entry["path"])
+	assert.NotContains(t, entry, "outcome")
+}
+
+func lastAccessLogEntry(t *testing.T, logs []byte) map[string]interface{} {
+	var entry map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(logs), []byte("\n")) {
+		var e map[string]interface{}
+		if json.Unmarshal(line, &e) == nil && e["event"] == accessLogEvent {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatalf("no access log entry in %s", logs)
+	}
+	return entry
+}
@@ -0,0 +1,81 @@
+package people
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+)
+
+const (
+	pprofPrefix = "/debug/pprof/"
+	expvarPath  = "/debug/vars"
+	runtimePath = "/__runtime"
+	bytesPerMiB = 1 << 20
+)
+
+var startTime = time.Now()
+
+// RuntimeStats is a snapshot of the Go runtime of the service
+type RuntimeStats struct {
+	GoVersion     string  `json:"goVersion"`
+	NumCPU        int     `json:"numCpu"`
+	GOMAXPROCS    int     `json:"gomaxprocs"`
+	Goroutines    int     `json:"goroutines"`
+	UptimeSeconds float64 `json:"uptimeSeconds"`
+	HeapAllocMiB  float64 `json:"heapAllocMiB"`
+	HeapSysMiB    float64 `json:"heapSysMiB"`
+	HeapObjects   uint64  `json:"heapObjects"`
+	TotalAllocMiB float64 `json:"totalAllocMiB"`
+	SysMiB        float64 `json:"sysMiB"`
+	NumGC         uint32  `json:"numGc"`
+	LastGC        string  `json:"lastGc,omitempty"`
+	PauseTotalMs  float64 `json:"pauseTotalMs"`
+}
+
+// RegisterDiagnosticsHandlers exposes pprof profiles, expvar variables and runtime stats.
+// They reveal internals of the process and must only be registered on the admin port.
+func RegisterDiagnosticsHandlers(router *mux.Router) {
+	logger.Info("Registering diagnostics handlers")
+	router.HandleFunc(pprofPrefix+"cmdline", pprof.Cmdline)
+	router.HandleFunc(pprofPrefix+"profile", pprof.Profile)
+	router.HandleFunc(pprofPrefix+"symbol", pprof.Symbol)
+	router.HandleFunc(pprofPrefix+"trace", pprof.Trace)
+	router.PathPrefix(pprofPrefix).HandlerFunc(pprof.Index)
+	router.Handle(expvarPath, expvar.Handler())
+	router.HandleFunc(runtimePath, getRuntimeStats)
+}
+
+func getRuntimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := RuntimeStats{
+		GoVersion:     runtime.Version(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		Goroutines:    runtime.NumGoroutine(),
+		UptimeSeconds: time.Since(startTime).Seconds(),
+		HeapAllocMiB:  float64(mem.HeapAlloc) / bytesPerMiB,
+		HeapSysMiB:    float64(mem.HeapSys) / bytesPerMiB,
+		HeapObjects:   mem.HeapObjects,
+		TotalAllocMiB: float64(mem.TotalAlloc) / bytesPerMiB,
+		SysMiB:        float64(mem.Sys) / bytesPerMiB,
+		NumGC:         mem.NumGC,
+		PauseTotalMs:  durationMillis(time.Duration(mem.PauseTotalNs)),
+	}
+	if mem.LastGC != 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339)
+	}
+
+	w.Header().Set("Content-Type", contentTypeJson)
+	w.Header().Set("Cache-Control", "no-store")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(stats)
+}
@@ -0,0 +1,53 @@
+package people
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterDiagnosticsHandlers(t *testing.T) {
+	router := mux.NewRouter()
+	RegisterDiagnosticsHandlers(router)
+
+	tests := []struct {
+		path     string
+		contains string
+	}{
+		{pprofPrefix, "goroutine"},
+		{pprofPrefix + "goroutine?debug=1", "goroutine profile"},
+		{pprofPrefix + "heap?debug=1", "heap profile"},
+		{pprofPrefix + "cmdline", ""},
+		{expvarPath, "memstats"},
+	}
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newRequest("GET", test.path, ""))
+		assert.Equal(t, http.StatusOK, rec.Code, test.path)
+		assert.Contains(t, rec.Body.String(), test.contains, test.path)
+	}
+}
+
+func TestGetRuntimeStats(t *testing.T) {
+	router := mux.NewRouter()
+	RegisterDiagnosticsHandlers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", runtimePath, ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentTypeJson, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var stats RuntimeStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Equal(t, runtime.Version(), stats.GoVersion)
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.HeapAllocMiB)
+	assert.Positive(t, stats.GOMAXPROCS)
+}
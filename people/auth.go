@@ -0,0 +1,315 @@
+package people
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ScopePII allows the personal details of a person, their email address and birth year
+	ScopePII = "people:pii"
+	// ScopeSocial allows the social media profiles of a person
+	ScopeSocial = "people:social"
+
+	anonymousPrincipal = "anonymous"
+
+	authenticationRequiredMsg = "Authentication required"
+	invalidCredentialsMsg     = "Invalid credentials"
+)
+
+// scopedFields lists, by JSON name, the person fields that are only returned to clients holding the scope
+var scopedFields = map[string][]string{
+	ScopePII:    {"emailAddress", "birthYear"},
+	ScopeSocial: {"twitterHandle", "facebookProfile"},
+}
+
+var (
+	errNoCredentials      = errors.New("no credentials")
+	errInvalidCredentials = errors.New("invalid credentials")
+)
+
+// APIKey is a key a client authenticates with, and the scopes it grants
+type APIKey struct {
+	Name   string
+	Key    string
+	Scopes []string
+}
+
+// ParseAPIKeys parses API keys in the form name:key:scope scope, separated by commas or new lines.
+// Lines starting with # are ignored.
+func ParseAPIKeys(specs string) ([]APIKey, error) {
+	var keys []APIKey
+	for _, line := range strings.Split(specs, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, spec := range strings.Split(line, ",") {
+			spec = strings.TrimSpace(spec)
+			if spec == "" {
+				continue
+			}
+			// errors name the client rather than quoting the spec, which holds its key
+			parts := strings.SplitN(spec, ":", 3)
+			if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("invalid API key for client %q, expected name:key:scopes", parts[0])
+			}
+			key := APIKey{Name: parts[0], Key: parts[1]}
+			if len(parts) == 3 {
+				key.Scopes = strings.Fields(parts[2])
+			}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// LoadAPIKeysFile reads API keys in the format of ParseAPIKeys, one per line
+func LoadAPIKeysFile(path string) ([]APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read API keys file: %w", err)
+	}
+	return ParseAPIKeys(string(data))
+}
+
+// Principal is the client making a request and the scopes it holds
+type Principal struct {
+	Name   string
+	Scopes []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return containsString(p.Scopes, scope)
+}
+
+// hiddenFields returns the scoped person fields the principal is not allowed to see
+func (p Principal) hiddenFields() map[string]bool {
+	hidden := map[string]bool{}
+	for scope, fields := range scopedFields {
+		if p.HasScope(scope) {
+			continue
+		}
+		for _, field := range fields {
+			hidden[field] = true
+		}
+	}
+	return hidden
+}
+
+// seesScopedFields reports whether the principal is allowed any scoped field, so responses to it must not be shared
+func (p Principal) seesScopedFields() bool {
+	for scope := range scopedFields {
+		if p.HasScope(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func withPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// principalFromContext returns the authenticated client. There is none when authentication is disabled,
+// in which case every field is returned.
+func principalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// AuthConfig configures how clients authenticate. Clients present an API key in KeyHeader, or a JWT as a bearer
+// token when JWKS is set. Clients without credentials are anonymous with AnonymousScopes, unless Required is set.
+type AuthConfig struct {
+	KeyHeader       string
+	APIKeys         []APIKey
+	JWKS            *JWKS
+	Issuer          string
+	Audience        string
+	Required        bool
+	AnonymousScopes []string
+}
+
+type Authenticator struct {
+	config AuthConfig
+	now    func() time.Time
+}
+
+func NewAuthenticator(config AuthConfig) *Authenticator {
+	return &Authenticator{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Handler authenticates API requests and rejects invalid credentials with a 401. Admin endpoints are not authenticated.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdminPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Authorization")
+		if a.config.KeyHeader != "" {
+			w.Header().Add("Vary", a.config.KeyHeader)
+		}
+
+		principal, err := a.authenticate(r.Header.Get(a.config.KeyHeader), r.Header.Get("Authorization"))
+		if err != nil {
+			transId := transactionidutils.GetTransactionIDFromRequest(r)
+			w.Header().Set("X-Request-Id", transId)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="public-people-api"`)
+			msg := invalidCredentialsMsg
+			if errors.Is(err, errNoCredentials) {
+				msg = authenticationRequiredMsg
+			}
+			logger.WithError(err).WithTransactionID(transId).Warn(msg)
+			writeJSONStatus(w, msg, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(withPrincipal(r.Context(), principal)))
+	})
+}
+
+// UnaryServerInterceptor authenticates gRPC calls from the API key or authorization metadata
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticateGRPC(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates gRPC streams from the API key or authorization metadata
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticateGRPC(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) authenticateGRPC(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, "/grpc.health.") {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	principal, err := a.authenticate(first(strings.ToLower(a.config.KeyHeader)), first("authorization"))
+	if errors.Is(err, errNoCredentials) {
+		return nil, status.Error(codes.Unauthenticated, authenticationRequiredMsg)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, invalidCredentialsMsg)
+	}
+	return withPrincipal(ctx, principal), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate identifies the client from an API key or an authorization header
+func (a *Authenticator) authenticate(apiKey, authorization string) (Principal, error) {
+	if apiKey != "" {
+		for _, key := range a.config.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key.Key)) == 1 {
+				return Principal{Name: key.Name, Scopes: key.Scopes}, nil
+			}
+		}
+		return Principal{}, fmt.Errorf("%w: unknown API key", errInvalidCredentials)
+	}
+	if authorization != "" {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if a.config.JWKS == nil || token == authorization {
+			return Principal{}, fmt.Errorf("%w: unsupported authorization", errInvalidCredentials)
+		}
+		claims, err := a.config.JWKS.verify(token, a.config.Issuer, a.config.Audience, a.now())
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %v", errInvalidCredentials, err)
+		}
+		return Principal{Name: claims.name(), Scopes: claims.scopes()}, nil
+	}
+	if a.config.Required {
+		return Principal{}, errNoCredentials
+	}
+	return Principal{Name: anonymousPrincipal, Scopes: a.config.AnonymousScopes}, nil
+}
+
+// filterPerson removes the fields the principal of the request is not allowed to see from a converted person
+func filterPerson(ctx context.Context, person interface{}) interface{} {
+	principal, ok := principalFromContext(ctx)
+	if !ok {
+		return person
+	}
+	hidden := principal.hiddenFields()
+	switch p := person.(type) {
+	case Person:
+		if hidden["emailAddress"] {
+			p.EmailAddress = ""
+		}
+		if hidden["birthYear"] {
+			p.BirthYear = 0
+		}
+		if hidden["twitterHandle"] {
+			p.TwitterHandle = ""
+		}
+		if hidden["facebookProfile"] {
+			p.FacebookProfile = ""
+		}
+		return p
+	case PersonV2:
+		if hidden["birthYear"] {
+			p.BirthYear = 0
+		}
+		if p.Accounts != nil {
+			accounts := *p.Accounts
+			if hidden["emailAddress"] {
+				accounts.EmailAddress = ""
+			}
+			if hidden["twitterHandle"] {
+				accounts.TwitterHandle = ""
+			}
+			if hidden["facebookProfile"] {
+				accounts.FacebookProfile = ""
+			}
+			p.Accounts = &accounts
+			if accounts == (AccountsV2{}) {
+				p.Accounts = nil
+			}
+		}
+		return p
+	}
+	return person
+}
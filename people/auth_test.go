@@ -0,0 +1,221 @@
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/people/peoplepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+const authTestUUID = "60e54253-1e94-38df-83b1-a39804d1ac18"
+
+var authTestKeys = []APIKey{
+	{Name: "next", Key: "next-key", Scopes: []string{ScopePII, ScopeSocial}},
+	{Name: "spark", Key: "spark-key", Scopes: []string{ScopeSocial}},
+}
+
+func newAuthTestRouter(config AuthConfig) http.Handler {
+	router := newTestRouter(time.Minute)
+	router.HandleFunc("/__gtg", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("{}")) })
+	return NewAuthenticator(config).Handler(router)
+}
+
+func getAuthTestPerson(t *testing.T, handler http.Handler, path string, headers map[string]string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	req := newRequest("GET", path, "")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	person := map[string]interface{}{}
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &person))
+	}
+	return rec, person
+}
+
+func TestAuthenticator_FiltersScopedFields(t *testing.T) {
+	logger.InitDefaultLogger("auth-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	handler := newAuthTestRouter(AuthConfig{KeyHeader: "X-Api-Key", APIKeys: authTestKeys})
+
+	rec, person := getAuthTestPerson(t, handler, "/people/"+authTestUUID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, person, "emailAddress")
+	assert.NotContains(t, person, "birthYear")
+	assert.NotContains(t, person, "twitterHandle")
+	assert.NotContains(t, person, "facebookProfile")
+	assert.Equal(t, "Neil Cole", person["prefLabel"])
+	assert.Equal(t, "max-age=60, public", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, []string{"Authorization", "X-Api-Key", "Accept"}, rec.Header().Values("Vary"))
+
+	rec, person = getAuthTestPerson(t, handler, "/people/"+authTestUUID, map[string]string{"X-Api-Key": "spark-key"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, person, "emailAddress")
+	assert.NotContains(t, person, "birthYear")
+	assert.Equal(t, "@ft", person["twitterHandle"])
+	assert.Equal(t, "max-age=60, private", rec.Header().Get("Cache-Control"))
+
+	rec, person = getAuthTestPerson(t, handler, "/people/"+authTestUUID, map[string]string{"X-Api-Key": "next-key"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "example@example.com", person["emailAddress"])
+	assert.EqualValues(t, 1957, person["birthYear"])
+
+	rec, person = getAuthTestPerson(t, handler, "/v2/people/"+authTestUUID, map[string]string{"X-Api-Key": "spark-key"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, person, "birthYear")
+	assert.Equal(t, map[string]interface{}{"twitterHandle": "@ft", "facebookProfile": "https://www.facebook.com/financialtimes/"}, person["accounts"])
+
+	_, person = getAuthTestPerson(t, handler, "/v2/people/"+authTestUUID, nil)
+	assert.NotContains(t, person, "accounts", "accounts should be dropped when all of them are hidden")
+}
+
+func TestAuthenticator_Rejections(t *testing.T) {
+	logger.InitDefaultLogger("auth-test")
+	signer := newECSigner(t, "ec-1")
+	handler := newAuthTestRouter(AuthConfig{KeyHeader: "X-Api-Key", APIKeys: authTestKeys, JWKS: testJWKS(t, signer), Required: true})
+	expired := signer.sign(t, map[string]interface{}{"sub": "next", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+		message string
+	}{
+		{"no credentials", "/people/" + authTestUUID, nil, http.StatusUnauthorized, authenticationRequiredMsg},
+		{"unknown API key", "/people/" + authTestUUID, map[string]string{"X-Api-Key": "wrong"}, http.StatusUnauthorized, invalidCredentialsMsg},
+		{"expired token", "/people/" + authTestUUID, map[string]string{"Authorization": "Bearer " + expired}, http.StatusUnauthorized, invalidCredentialsMsg},
+		{"basic authorization", "/people/" + authTestUUID, map[string]string{"Authorization": "Basic bmV4dDprZXk="}, http.StatusUnauthorized, invalidCredentialsMsg},
+		{"admin endpoint", "/__gtg", nil, http.StatusOK, ""},
+	}
+	for _, test := range tests {
+		rec, _ := getAuthTestPerson(t, handler, test.path, test.headers)
+		assert.Equal(t, test.status, rec.Code, test.name)
+		if test.message != "" {
+			assert.JSONEq(t, fmt.Sprintf(`{"message": %q}`, test.message), rec.Body.String(), test.name)
+			assert.Equal(t, `Bearer realm="public-people-api"`, rec.Header().Get("WWW-Authenticate"), test.name)
+		}
+	}
+}
+
+func TestAuthenticator_JWT(t *testing.T) {
+	logger.InitDefaultLogger("auth-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	signer := newRSASigner(t, "rsa-1")
+	handler := newAuthTestRouter(AuthConfig{JWKS: testJWKS(t, signer), Audience: "public-people-api", AnonymousScopes: []string{ScopeSocial}})
+	token := signer.sign(t, map[string]interface{}{"sub": "next", "aud": "public-people-api", "exp": time.Now().Add(time.Hour).Unix(), "scope": ScopePII})
+
+	rec, person := getAuthTestPerson(t, handler, "/people/"+authTestUUID, map[string]string{"Authorization": "Bearer " + token})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "example@example.com", person["emailAddress"])
+	assert.NotContains(t, person, "twitterHandle")
+
+	rec, person = getAuthTestPerson(t, handler, "/people/"+authTestUUID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, person, "emailAddress")
+	assert.Equal(t, "@ft", person["twitterHandle"], "anonymous clients should get the anonymous scopes")
+}
+
+func TestAuthenticator_GRPC(t *testing.T) {
+	logger.InitDefaultLogger("auth-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	keys := append([]APIKey{{Name: "unscoped", Key: "unscoped-key"}}, authTestKeys...)
+	auth := NewAuthenticator(AuthConfig{KeyHeader: "X-Api-Key", APIKeys: keys, Required: true})
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor()), grpc.StreamInterceptor(auth.StreamServerInterceptor()))
+	NewGRPCServer(NewHandler(0, "http://localhost:8080", http.DefaultClient)).Register(server)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := peoplepb.NewPeopleServiceClient(conn)
+
+	_, err = client.GetPerson(context.Background(), &peoplepb.GetPersonRequest{Uuid: authTestUUID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "spark-key")
+	var header metadata.MD
+	person, err := client.GetPerson(ctx, &peoplepb.GetPersonRequest{Uuid: authTestUUID}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Empty(t, person.GetEmailAddress())
+	assert.Zero(t, person.GetBirthYear())
+	assert.Equal(t, "@ft", person.GetTwitterHandle())
+	assert.Equal(t, []string{"max-age=0, private"}, header.Get("cache-control"), "responses with scoped fields should not be shared")
+
+	_, err = client.BatchGetPeople(ctx, &peoplepb.BatchGetPeopleRequest{Uuids: []string{authTestUUID}}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"max-age=0, private"}, header.Get("cache-control"))
+
+	stream, err := client.StreamPeople(ctx, &peoplepb.StreamPeopleRequest{Uuids: []string{authTestUUID}})
+	require.NoError(t, err)
+	result, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Empty(t, result.GetPerson().GetEmailAddress())
+	assert.Equal(t, "@ft", result.GetPerson().GetTwitterHandle())
+	header, err = stream.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"max-age=0, private"}, header.Get("cache-control"))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "unscoped-key")
+	_, err = client.GetPerson(ctx, &peoplepb.GetPersonRequest{Uuid: authTestUUID}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"max-age=0, public"}, header.Get("cache-control"))
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys("next:next-key:people:pii people:social, spark:spark-key")
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{
+		{Name: "next", Key: "next-key", Scopes: []string{ScopePII, ScopeSocial}},
+		{Name: "spark", Key: "spark-key"},
+	}, keys)
+
+	path := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(path, []byte("# clients\nnext:next-key:people:pii\n\nspark:spark-key:people:social\n"), 0600))
+	keys, err = LoadAPIKeysFile(path)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, []string{ScopePII}, keys[0].Scopes)
+
+	for _, spec := range []string{"next", "next:", ":secret-key:people:pii"} {
+		_, err := ParseAPIKeys(spec)
+		assert.Error(t, err, spec)
+		assert.NotContains(t, err.Error(), "secret-key")
+	}
+}
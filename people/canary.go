@@ -0,0 +1,121 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+)
+
+const personType = "http://www.ft.com/ontology/person/Person"
+
+var errCanaryNotChecked = errors.New("canary person has not been checked yet")
+
+// CanaryCheck periodically looks up a known person end to end, through public-concepts-api and the conversion,
+// and keeps the latest result so that health checks can report it without calling upstream
+type CanaryCheck struct {
+	handler  *Handler
+	uuid     string
+	interval time.Duration
+
+	mu        sync.RWMutex
+	checkedAt time.Time
+	latency   time.Duration
+	err       error
+}
+
+func NewCanaryCheck(h *Handler, uuid string, interval time.Duration) *CanaryCheck {
+	return &CanaryCheck{
+		handler:  h,
+		uuid:     uuid,
+		interval: interval,
+		err:      errCanaryNotChecked,
+	}
+}
+
+// Start checks the canary person straight away and then on every interval until ctx is done
+func (c *CanaryCheck) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			c.Run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run looks up the canary person once and records the result
+func (c *CanaryCheck) Run(ctx context.Context) {
+	tid := transactionidutils.NewTransactionID()
+	start := time.Now()
+	err := c.lookup(ctx, tid)
+	latency := time.Since(start)
+	if err != nil {
+		logger.WithError(err).WithTransactionID(tid).WithUUID(c.uuid).Warn("Canary person check failed")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkedAt, c.latency, c.err = time.Now(), latency, err
+}
+
+func (c *CanaryCheck) lookup(ctx context.Context, tid string) error {
+	person, found, err := c.handler.getPersonViaConceptsAPI(ctx, c.uuid, tid)
+	if err != nil {
+		return fmt.Errorf("canary person %s could not be retrieved: %w", c.uuid, err)
+	}
+	if !found {
+		return fmt.Errorf("canary person %s was not found", c.uuid)
+	}
+	if !containsString(person.Types, personType) {
+		return fmt.Errorf("canary person %s has types %v, expected %s", c.uuid, person.Types, personType)
+	}
+	if person.PrefLabel == "" {
+		return fmt.Errorf("canary person %s has no prefLabel", c.uuid)
+	}
+	return nil
+}
+
+// Checker reports the latest cached result
+func (c *CanaryCheck) Checker() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.err != nil {
+		if c.checkedAt.IsZero() {
+			return "", c.err
+		}
+		return "", fmt.Errorf("%v (checked at %s in %s)", c.err, c.checkedAt.Format(time.RFC3339), c.latency)
+	}
+	return fmt.Sprintf("Canary person %s retrieved in %s at %s", c.uuid, c.latency, c.checkedAt.Format(time.RFC3339)), nil
+}
+
+func (c *CanaryCheck) Healthcheck() fthealth.Check {
+	return fthealth.Check{
+		ID:               "canary-person-check",
+		BusinessImpact:   "Public People API may be serving errors or incorrect people",
+		Name:             "Check a known person can be retrieved end to end",
+		PanicGuide:       "https://dewey.in.ft.com/runbooks/public-people-api",
+		Severity:         2,
+		TechnicalSummary: fmt.Sprintf("Person %s is periodically retrieved through public-concepts-api and converted. A failure means person lookups are broken even if public-concepts-api is reachable.", c.uuid),
+		Checker:          c.Checker,
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package people
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestCanaryCheck(t *testing.T) {
+	logger.InitDefaultLogger("canary-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	url := "http://localhost:8080/concepts/" + uuid
+	canary := NewCanaryCheck(NewHandler(0, "http://localhost:8080", http.DefaultClient), uuid, 0)
+
+	_, err := canary.Checker()
+	assert.Equal(t, errCanaryNotChecked, err)
+
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		err      string
+	}{
+		{"valid person", 200, `{"id": "http://www.ft.com/thing/` + uuid + `", "prefLabel": "Neil Cole", "type": "http://www.ft.com/ontology/person/Person"}`, ""},
+		{"missing prefLabel", 200, `{"id": "http://www.ft.com/thing/` + uuid + `", "type": "http://www.ft.com/ontology/person/Person"}`, "has no prefLabel"},
+		{"not a person", 200, `{"id": "http://www.ft.com/thing/` + uuid + `", "prefLabel": "FT", "type": "http://www.ft.com/ontology/product/Brand"}`, "was not found"},
+		{"not found", 404, `{}`, "was not found"},
+		{"invalid response", 200, `<html>`, "could not be retrieved"},
+	}
+	for _, test := range tests {
+		httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(test.status, test.response))
+		canary.Run(context.Background())
+
+		output, err := canary.Checker()
+		if test.err == "" {
+			assert.NoError(t, err, test.name)
+			assert.Contains(t, output, "retrieved in", test.name)
+		} else {
+			assert.Error(t, err, test.name)
+			assert.Contains(t, err.Error(), test.err, test.name)
+			assert.Contains(t, err.Error(), "checked at", test.name)
+		}
+	}
+}
+
+func TestCanaryCheck_CachesResult(t *testing.T) {
+	logger.InitDefaultLogger("canary-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+		httpmock.NewStringResponder(200, `{"id": "http://www.ft.com/thing/`+uuid+`", "prefLabel": "Neil Cole", "type": "http://www.ft.com/ontology/person/Person"}`))
+
+	canary := NewCanaryCheck(NewHandler(0, "http://localhost:8080", http.DefaultClient), uuid, 0)
+	canary.Run(context.Background())
+	calls := httpmock.GetTotalCallCount()
+
+	for i := 0; i < 3; i++ {
+		_, err := canary.Healthcheck().Checker()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, calls, httpmock.GetTotalCallCount())
+}
@@ -0,0 +1,256 @@
+package people
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+
+	// brotliLevel favours speed over ratio, as responses are compressed as they are served
+	brotliLevel = 5
+)
+
+// CompressionConfig configures the compression of responses. Encodings are the supported content encodings, br and
+// gzip, in order of preference when a client accepts several equally. Responses smaller than MinSize bytes are not
+// compressed, as the saving does not make up for the cost.
+type CompressionConfig struct {
+	Encodings []string
+	MinSize   int
+}
+
+// ParseCompressionEncodings parses comma separated content encodings, in order of preference
+func ParseCompressionEncodings(specs string) ([]string, error) {
+	var encodings []string
+	for _, encoding := range SplitList(specs) {
+		encoding = strings.ToLower(encoding)
+		if encoding != encodingBrotli && encoding != encodingGzip {
+			return nil, fmt.Errorf("unsupported encoding %q, expected %s or %s", encoding, encodingBrotli, encodingGzip)
+		}
+		if containsString(encodings, encoding) {
+			return nil, fmt.Errorf("duplicate encoding %q", encoding)
+		}
+		encodings = append(encodings, encoding)
+	}
+	return encodings, nil
+}
+
+// encoder is a compressing writer that can be reused for another response
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+type Compressor struct {
+	config   CompressionConfig
+	encoders map[string]*sync.Pool
+}
+
+func NewCompressor(config CompressionConfig) *Compressor {
+	return &Compressor{
+		config: config,
+		encoders: map[string]*sync.Pool{
+			encodingGzip: {New: func() interface{} {
+				w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+				return w
+			}},
+			encodingBrotli: {New: func() interface{} {
+				return brotli.NewWriterLevel(io.Discard, brotliLevel)
+			}},
+		},
+	}
+}
+
+// Handler compresses API responses with the preferred encoding the client accepts. Admin endpoints are not compressed.
+func (c *Compressor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdminPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := c.negotiate(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, compressor: c, encoding: encoding, head: r.Method == http.MethodHead}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiate returns the supported encoding with the highest quality in the Accept-Encoding header, or none
+func (c *Compressor) negotiate(acceptEncoding string) string {
+	qualities := map[string]float64{}
+	for _, accepted := range SplitList(acceptEncoding) {
+		name, params, _ := strings.Cut(accepted, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range c.config.Encodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+func (c *Compressor) encoder(encoding string, w io.Writer) encoder {
+	e := c.encoders[encoding].Get().(encoder)
+	e.Reset(w)
+	return e
+}
+
+func (c *Compressor) release(encoding string, e encoder) {
+	e.Reset(io.Discard)
+	c.encoders[encoding].Put(e)
+}
+
+// compressWriter holds back the response until it knows whether it is large enough to compress, from its
+// Content-Length or from the size of the body written so far
+type compressWriter struct {
+	http.ResponseWriter
+	compressor *Compressor
+	encoding   string
+	head       bool
+
+	status  int
+	started bool
+	buf     []byte
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.started || w.status != 0 {
+		return
+	}
+	w.status = status
+	if !bodyAllowed(status) {
+		w.start(false)
+		return
+	}
+	if length := w.Header().Get("Content-Length"); length != "" {
+		size, _ := strconv.Atoi(length)
+		w.start(size >= w.compressor.config.MinSize)
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.compressor.config.MinSize {
+			return len(b), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush compresses the response, as one that is flushed is likely streamed, and sends what is compressed so far
+func (w *compressWriter) Flush() {
+	if !w.started {
+		w.start(true)
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// start writes the headers, compressed when asked and the response allows it, and any body held back
+func (w *compressWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	h := w.Header()
+	if h.Get("Content-Type") == "" && len(w.buf) > 0 {
+		h.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+	if compress && bodyAllowed(w.status) && h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		if etag := h.Get("ETag"); etag != "" {
+			h.Set("ETag", encodedETag(etag, w.encoding))
+		}
+		if !w.head {
+			w.encoder = w.compressor.encoder(w.encoding, w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (w *compressWriter) close() {
+	if !w.started {
+		w.start(false)
+	}
+	if w.encoder != nil {
+		w.encoder.Close()
+		w.compressor.release(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// compressible reports whether responses of the content type are text, and so worth compressing
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json") || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// encodedETag derives the validator of a compressed response from the validator of the uncompressed one, as their
+// bodies differ
+func encodedETag(etag, encoding string) string {
+	if !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
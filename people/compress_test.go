@@ -0,0 +1,149 @@
+package people
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+var compressionTestConfig = CompressionConfig{Encodings: []string{encodingBrotli, encodingGzip}, MinSize: 256}
+
+func newCompressionTestRouter(config CompressionConfig) http.Handler {
+	router := newTestRouter(time.Minute)
+	router.HandleFunc("/__health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bytes.Repeat([]byte(" "), 1024))
+	})
+	router.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson+json")
+		w.Write([]byte(`{"n":1}` + "\n"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte(`{"n":2}` + "\n"))
+	})
+	return NewCompressor(config).Handler(router)
+}
+
+func serveCompressed(handler http.Handler, method, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := newRequest(method, path, "")
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func decompress(t *testing.T, encoding string, body []byte) []byte {
+	var r io.Reader
+	switch encoding {
+	case encodingGzip:
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		r = gz
+	case encodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return body
+	}
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func TestCompressor_Negotiate(t *testing.T) {
+	c := NewCompressor(compressionTestConfig)
+	tests := map[string]string{
+		"":                          "",
+		"identity":                  "",
+		"gzip":                      encodingGzip,
+		"gzip, deflate, br":         encodingBrotli,
+		"GZIP;q=1, br;q=0.5":        encodingGzip,
+		"br;q=0, gzip;q=0.1":        encodingGzip,
+		"*":                         encodingBrotli,
+		"*;q=0.5, br;q=0":           encodingGzip,
+		"deflate, gzip;q=bad":       "",
+		"compress, gzip;q=0, *;q=0": "",
+	}
+	for acceptEncoding, expected := range tests {
+		assert.Equal(t, expected, c.negotiate(acceptEncoding), acceptEncoding)
+	}
+}
+
+func TestCompressor_Person(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+	handler := newCompressionTestRouter(compressionTestConfig)
+
+	identity := serveCompressed(handler, "GET", "/people/"+authTestUUID, "")
+	require.Equal(t, http.StatusOK, identity.Code)
+	assert.Empty(t, identity.Header().Get("Content-Encoding"))
+	assert.Equal(t, []string{"Accept-Encoding", "Accept"}, identity.Header().Values("Vary"))
+
+	for _, encoding := range []string{encodingGzip, encodingBrotli} {
+		rec := serveCompressed(handler, "GET", "/people/"+authTestUUID, encoding)
+		assert.Equal(t, http.StatusOK, rec.Code, encoding)
+		assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Header().Get("Content-Length"), encoding)
+		assert.Equal(t, strings.TrimSuffix(identity.Header().Get("ETag"), `"`)+"-"+encoding+`"`, rec.Header().Get("ETag"))
+		assert.Equal(t, identity.Header().Get("Content-Type"), rec.Header().Get("Content-Type"))
+		assert.Less(t, rec.Body.Len(), identity.Body.Len(), encoding)
+		assert.Equal(t, identity.Body.String(), string(decompress(t, encoding, rec.Body.Bytes())), encoding)
+
+		head := serveCompressed(handler, "HEAD", "/people/"+authTestUUID, encoding)
+		assert.Equal(t, http.StatusOK, head.Code)
+		assert.Empty(t, head.Body.Bytes())
+		for _, header := range []string{"Content-Encoding", "Content-Length", "ETag", "Vary"} {
+			assert.Equal(t, rec.Header().Values(header), head.Header().Values(header), header)
+		}
+	}
+}
+
+func TestCompressor_SmallResponse(t *testing.T) {
+	rec := serveCompressed(newCompressionTestRouter(compressionTestConfig), "GET", "/people/BOO", "gzip")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, `{"message":"Invalid UUID"}`, rec.Body.String())
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+}
+
+func TestCompressor_Stream(t *testing.T) {
+	rec := serveCompressed(newCompressionTestRouter(compressionTestConfig), "GET", "/stream", "gzip")
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, encodingGzip, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "{\"n\":1}\n{\"n\":2}\n", string(decompress(t, encodingGzip, rec.Body.Bytes())))
+}
+
+func TestCompressor_SkipsAdminEndpoints(t *testing.T) {
+	rec := serveCompressed(newCompressionTestRouter(compressionTestConfig), "GET", "/__health", "gzip")
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Empty(t, rec.Header().Values("Vary"))
+	assert.Equal(t, 1024, rec.Body.Len())
+}
+
+func TestParseCompressionEncodings(t *testing.T) {
+	encodings, err := ParseCompressionEncodings("GZIP, br")
+	require.NoError(t, err)
+	assert.Equal(t, []string{encodingGzip, encodingBrotli}, encodings)
+
+	_, err = ParseCompressionEncodings("gzip, deflate")
+	assert.EqualError(t, err, `unsupported encoding "deflate", expected br or gzip`)
+	_, err = ParseCompressionEncodings("gzip, gzip")
+	assert.EqualError(t, err, `duplicate encoding "gzip"`)
+
+	encodings, err = ParseCompressionEncodings("")
+	require.NoError(t, err)
+	assert.Empty(t, encodings)
+}
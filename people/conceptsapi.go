@@ -0,0 +1,158 @@
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPConceptSource reads concepts from public-concepts-api
+type HTTPConceptSource struct {
+	url     string
+	client  *http.Client
+	metrics *Metrics
+}
+
+// NewHTTPConceptSource reads concepts from the public-concepts-api at url, recording its requests in metrics, which
+// may be nil
+func NewHTTPConceptSource(url string, c *http.Client, metrics *Metrics) *HTTPConceptSource {
+	return &HTTPConceptSource{url: url, client: c, metrics: metrics}
+}
+
+func (s *HTTPConceptSource) GetConcept(ctx context.Context, uuid string) (Concept, error) {
+	c, _, err := s.getConcept(ctx, uuid)
+	return c, err
+}
+
+// getConcept also returns the UUID the concept was served for, which is the canonical UUID when public-concepts-api
+// redirected a concorded UUID to it
+func (s *HTTPConceptSource) getConcept(ctx context.Context, uuid string) (Concept, string, error) {
+	var c Concept
+
+	resp, err := s.fetch(ctx, uuid)
+	if err != nil {
+		return c, uuid, err
+	}
+
+	if resp.Status == http.StatusNotFound {
+		return c, uuid, ErrConceptNotFound
+	}
+
+	if err := json.Unmarshal(resp.Body, &c); err != nil {
+		tid, _ := transactionidutils.GetTransactionIDFromContext(ctx)
+		logger.WithError(logSafeJSONError(err)).WithTransactionID(tid).Warnf("Error parsing json")
+		return c, uuid, err
+	}
+	return c, resp.ServedUUID, nil
+}
+
+func (s *HTTPConceptSource) String() string {
+	return s.url
+}
+
+// upstreamResponse is the exchange with public-concepts-api for a single concept. ServedUUID is the UUID of the
+// concept URL that answered, after any redirects.
+type upstreamResponse struct {
+	URL        string
+	Status     int
+	Duration   time.Duration
+	Body       []byte
+	ServedUUID string
+}
+
+func (s *HTTPConceptSource) fetch(ctx context.Context, uuid string) (upstream upstreamResponse, err error) {
+	tid, _ := transactionidutils.GetTransactionIDFromContext(ctx)
+	ctx, span := tracer().Start(ctx, "getConcept", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("person.uuid", uuid),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	u, err := url.Parse(s.url)
+	if err != nil {
+		msg := fmt.Sprintf("URL of Concepts API is invalid of %s", uuid)
+		logger.WithError(err).WithUUID(uuid).WithTransactionID(tid).Error(msg)
+		return upstream, err
+	}
+
+	u.Path = "/concepts/" + uuid
+	q := u.Query()
+	for _, query := range []string{"related"} {
+		q.Add("showRelationship", query)
+	}
+	u.RawQuery = q.Encode()
+	upstream.URL = u.String()
+	req, err := http.NewRequestWithContext(ctx, "GET", upstream.URL, nil)
+	if err != nil {
+		return upstream, err
+	}
+	req.Header.Set("X-Request-Id", tid)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	span.SetAttributes(semconv.URLFull(upstream.URL))
+
+	done := s.metrics.upstreamStarted()
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	upstream.Duration = time.Since(start)
+	if err != nil {
+		done(0)
+		accessRecordFromContext(ctx).addUpstream(0, upstream.Duration)
+		logger.WithError(err).WithTransactionID(tid).Warnf("API request failed")
+		return upstream, err
+	}
+	defer resp.Body.Close()
+	upstream.Status = resp.StatusCode
+	upstream.ServedUUID = uuid
+	if resp.Request != nil {
+		upstream.ServedUUID = path.Base(resp.Request.URL.Path)
+	}
+	done(resp.StatusCode)
+	accessRecordFromContext(ctx).addUpstream(resp.StatusCode, upstream.Duration)
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	if upstream.Body, err = ioutil.ReadAll(resp.Body); err != nil {
+		logger.WithError(err).WithTransactionID(tid).Warnf("Error reading response body")
+		return upstream, err
+	}
+	return upstream, nil
+}
+
+// Checker checks public-concepts-api is good to go
+func (s *HTTPConceptSource) Checker() (string, error) {
+	req, err := http.NewRequest("GET", s.url+"/__gtg", nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Add("User-Agent", "UPP public-people-api")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("health check returned a non-200 HTTP status: %v", resp.StatusCode)
+	}
+	return "Public Concepts API is healthy", nil
+}
@@ -0,0 +1,65 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultConcordanceDepth is the number of canonical ID hops followed unless configured otherwise
+	defaultConcordanceDepth = 5
+
+	concordanceLoopMsg    = "Person %s could not be resolved, its concordance chain loops"
+	concordanceTooLongMsg = "Person %s could not be resolved, its concordance chain is longer than %d"
+
+	concordanceErrorLoop    = "loop"
+	concordanceErrorTooLong = "too_long"
+)
+
+var (
+	errConcordanceLoop    = errors.New("concordance chain loops")
+	errConcordanceTooLong = errors.New("concordance chain is too long")
+)
+
+// WithConcordanceDepth sets the number of canonical ID hops followed to resolve a person
+func WithConcordanceDepth(depth int) HandlerOption {
+	return func(h *Handler) {
+		h.concordanceDepth = depth
+	}
+}
+
+// resolvePersonConcept follows the canonical IDs of the person from uuid until it reaches a concept that is its own
+// canonical person, and returns it with the UUIDs of the chain, starting with uuid. Loops and chains longer than the
+// concordance depth are errors. A concept public-concepts-api redirected to is not read again when it is the
+// canonical person of the UUID it was served for.
+func (h *Handler) resolvePersonConcept(ctx context.Context, uuid, tid string) (concept Concept, chain []string, found bool, err error) {
+	chain = []string{uuid}
+	for {
+		var served string
+		concept, served, found, err = h.getPersonConcept(ctx, chain[len(chain)-1], tid)
+		if err != nil || !found {
+			return concept, chain, found, err
+		}
+
+		canonicalId := conceptUUID(concept)
+		if served != chain[len(chain)-1] && canonicalId == served && !containsString(chain, served) && len(chain) <= h.concordanceDepth {
+			chain = append(chain, served)
+		}
+		current := chain[len(chain)-1]
+		switch {
+		case canonicalId == current:
+			h.metrics.observeConcordanceChain(len(chain) - 1)
+			concept, _ = h.redactConcept(ctx, chain, concept)
+			return concept, chain, true, nil
+		case containsString(chain, canonicalId):
+			h.metrics.observeConcordanceError(concordanceErrorLoop)
+			return Concept{}, chain, false, fmt.Errorf("%w: %s", errConcordanceLoop, strings.Join(append(chain, canonicalId), " -> "))
+		case len(chain) > h.concordanceDepth:
+			h.metrics.observeConcordanceError(concordanceErrorTooLong)
+			return Concept{}, chain, false, fmt.Errorf("%w: %s", errConcordanceTooLong, strings.Join(append(chain, canonicalId), " -> "))
+		}
+		chain = append(chain, canonicalId)
+	}
+}
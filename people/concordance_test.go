@@ -0,0 +1,132 @@
+package people
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+const (
+	concordedUUID    = "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	intermediateUUID = "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"
+	finalUUID        = "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+)
+
+// registerConcordance registers each UUID with the concepts API as concorded to the next one, the last one to itself
+func registerConcordance(uuids ...string) {
+	for i, uuid := range uuids {
+		canonical := uuid
+		if i+1 < len(uuids) {
+			canonical = uuids[i+1]
+		}
+		httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+			httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, canonical, canonical, "")))
+	}
+}
+
+func scrapeMetrics(router http.Handler) string {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/metrics", ""))
+	return rec.Body.String()
+}
+
+func TestConcordance_ChainRedirectsToFinalPerson(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerConcordance(concordedUUID, intermediateUUID, finalUUID)
+	router := newTestRouter(0)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID, ""))
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/people/"+finalUUID, rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID+"?redirect=transparent", ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, finalUUID, rec.Header().Get(canonicalIDHeader))
+
+	body := scrapeMetrics(router)
+	assert.Contains(t, body, `public_people_api_concordance_chain_length_bucket{le="2"} 2`)
+	assert.Contains(t, body, `public_people_api_concordance_chain_length_bucket{le="1"} 0`)
+}
+
+func TestConcordance_RedirectedConceptIsNotReadAgain(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	calls := map[string]int{}
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+concordedUUID, func(req *http.Request) (*http.Response, error) {
+		calls[concordedUUID]++
+		resp := httpmock.NewStringResponse(http.StatusMovedPermanently, "")
+		resp.Header.Set("Location", "/concepts/"+finalUUID+"?"+req.URL.RawQuery)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+finalUUID, func(req *http.Request) (*http.Response, error) {
+		calls[finalUUID]++
+		resp := httpmock.NewStringResponse(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, finalUUID, finalUUID, ""))
+		// as set by real transports, telling which URL answered after the redirect
+		resp.Request = req
+		return resp, nil
+	})
+	router := newTestRouter(0)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID+"?redirect=transparent", ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, finalUUID, rec.Header().Get(canonicalIDHeader))
+	assert.Equal(t, map[string]int{concordedUUID: 1, finalUUID: 1}, calls, "the canonical concept should not be read again")
+	assert.Contains(t, scrapeMetrics(router), `public_people_api_concordance_chain_length_bucket{le="1"} 1`)
+}
+
+func TestConcordance_Loop(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerConcordance(concordedUUID, intermediateUUID)
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+intermediateUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, concordedUUID, concordedUUID, "")))
+	router := newTestRouter(0)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID, ""))
+	assert.Equal(t, http.StatusLoopDetected, rec.Code)
+	msg := &errMsg{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(msg))
+	assert.Equal(t, fmt.Sprintf(concordanceLoopMsg, concordedUUID), msg.Message)
+	assert.Empty(t, rec.Header().Get("Location"))
+
+	assert.Contains(t, scrapeMetrics(router), `public_people_api_concordance_errors_total{reason="loop"} 1`)
+}
+
+func TestConcordance_TooLong(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerConcordance(concordedUUID, intermediateUUID, finalUUID)
+	router := newTestRouter(0, WithConcordanceDepth(1))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID, ""))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	msg := &errMsg{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(msg))
+	assert.Equal(t, fmt.Sprintf(concordanceTooLongMsg, concordedUUID, 1), msg.Message)
+
+	assert.Contains(t, scrapeMetrics(router), `public_people_api_concordance_errors_total{reason="too_long"} 1`)
+}
+
+func TestConcordance_NotFoundCanonicalPerson(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerConcordance(concordedUUID, intermediateUUID)
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+intermediateUUID, httpmock.NewStringResponder(404, "Not found"))
+	router := newTestRouter(0)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+concordedUUID, ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,67 @@
+package people
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+var updateContracts = flag.Bool("update-contracts", false, "rewrite the contract golden files from the current output")
+
+const (
+	contractUUID           = "60e54253-1e94-38df-83b1-a39804d1ac18"
+	contractDeprecatedUUID = "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"
+)
+
+// contractTestSuite serves the complete concepts API fixture and compares responses byte for byte
+// with the golden files in testdata/contract/<version>
+type contractTestSuite struct {
+	suite.Suite
+	version string
+	router  *mux.Router
+}
+
+func (suite *contractTestSuite) SetupTest() {
+	logger.InitDefaultLogger("contract-test")
+	suite.router = mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient).RegisterHandlers(suite.router)
+
+	httpmock.Activate()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+contractUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, contractUUID, contractUUID, "")))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+contractDeprecatedUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, contractDeprecatedUUID, contractDeprecatedUUID, `"isDeprecated":true,`)))
+}
+
+func (suite *contractTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *contractTestSuite) get(path, accept string) *httptest.ResponseRecorder {
+	req := newRequest("GET", path, "")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (suite *contractTestSuite) assertGolden(name string, rec *httptest.ResponseRecorder) {
+	golden := filepath.Join("testdata", "contract", suite.version, name+".json")
+	if *updateContracts {
+		suite.Require().NoError(ioutil.WriteFile(golden, rec.Body.Bytes(), 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal(string(expected), rec.Body.String())
+}
@@ -0,0 +1,39 @@
+package people
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ContractV1TestSuite guards the original representation, which must stay byte-compatible for existing consumers
+type ContractV1TestSuite struct {
+	contractTestSuite
+}
+
+func (suite *ContractV1TestSuite) TestDefaultRepresentation() {
+	rec := suite.get("/people/"+contractUUID, "")
+	suite.assertGolden("complete", rec)
+	suite.Equal(contentTypeJson, rec.Header().Get("Content-Type"))
+}
+
+func (suite *ContractV1TestSuite) TestDeprecatedPerson() {
+	suite.assertGolden("deprecated", suite.get("/people/"+contractDeprecatedUUID, ""))
+}
+
+func (suite *ContractV1TestSuite) TestExplicitVersion() {
+	suite.assertGolden("complete", suite.get("/v1/people/"+contractUUID, ""))
+	suite.assertGolden("complete", suite.get("/people/"+contractUUID, "application/json; version=1"))
+	suite.assertGolden("complete", suite.get("/people/"+contractUUID, "text/html, application/json"))
+}
+
+func (suite *ContractV1TestSuite) TestUnsupportedVersion() {
+	rec := suite.get("/people/"+contractUUID, "application/json; version=9")
+	suite.Equal(http.StatusNotAcceptable, rec.Code)
+	suite.Equal(http.StatusNotAcceptable, suite.get("/v9/people/"+contractUUID, "").Code)
+}
+
+func TestContractV1TestSuite(t *testing.T) {
+	suite.Run(t, &ContractV1TestSuite{contractTestSuite{version: "v1"}})
+}
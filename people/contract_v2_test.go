@@ -0,0 +1,37 @@
+package people
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ContractV2TestSuite guards the version 2 representation
+type ContractV2TestSuite struct {
+	contractTestSuite
+}
+
+func (suite *ContractV2TestSuite) TestPathVersion() {
+	rec := suite.get("/v2/people/"+contractUUID, "")
+	suite.assertGolden("complete", rec)
+	suite.Equal(contentTypeJsonV2, rec.Header().Get("Content-Type"))
+}
+
+func (suite *ContractV2TestSuite) TestAcceptVersion() {
+	rec := suite.get("/people/"+contractUUID, "application/json; version=2")
+	suite.assertGolden("complete", rec)
+	suite.Equal(contentTypeJsonV2, rec.Header().Get("Content-Type"))
+	suite.Equal("Accept", rec.Header().Get("Vary"))
+}
+
+func (suite *ContractV2TestSuite) TestPathVersionTakesPrecedence() {
+	suite.assertGolden("complete", suite.get("/v2/people/"+contractUUID, "application/json; version=1"))
+}
+
+func (suite *ContractV2TestSuite) TestDeprecatedPerson() {
+	suite.assertGolden("deprecated", suite.get("/v2/people/"+contractDeprecatedUUID, ""))
+}
+
+func TestContractV2TestSuite(t *testing.T) {
+	suite.Run(t, &ContractV2TestSuite{contractTestSuite{version: "v2"}})
+}
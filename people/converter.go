@@ -1,6 +1,7 @@
 package people
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/Financial-Times/neo-model-utils-go/mapper"
@@ -25,19 +26,22 @@ func convertToPerson(concept Concept, p *Person) {
 	p.IsDeprecated = concept.IsDeprecated
 
 	for _, account := range concept.Account {
-		switch {
-		case strings.Contains(account.Type, "facebookProfile"):
-			p.FacebookProfile = account.Value.(string)
-		case strings.Contains(account.Type, "twitterHandle"):
-			p.TwitterHandle = account.Value.(string)
-		case strings.Contains(account.Type, "emailAddress"):
-			p.EmailAddress = account.Value.(string)
+		value, _ := account.Value.(string)
+		switch accountField(account.Type) {
+		case "facebookProfile":
+			p.FacebookProfile = value
+		case "twitterHandle":
+			p.TwitterHandle = value
+		case "emailAddress":
+			p.EmailAddress = value
 		}
 	}
 
 	var labels []string
 	for _, label := range concept.AlternativeLabels {
-		labels = append(labels, label.Value.(string))
+		if value, ok := label.Value.(string); ok {
+			labels = append(labels, value)
+		}
 	}
 	p.Labels = labels
 
@@ -48,6 +52,16 @@ func convertToPerson(concept Concept, p *Person) {
 	p.Memberships = memberships
 }
 
+// accountField returns the name of the person field an account of the type is served as
+func accountField(accountType string) string {
+	for _, field := range []string{"facebookProfile", "twitterHandle", "emailAddress"} {
+		if strings.Contains(accountType, field) {
+			return field
+		}
+	}
+	return ""
+}
+
 func convertToMembership(c Concept) *Membership {
 	var organisation Organisation
 	for _, related := range c.RelatedConcepts {
@@ -109,3 +123,52 @@ func convertApiUrl(conceptsApiUrl string, desired string) string {
 func convertID(conceptsApiID string) string {
 	return strings.Replace(conceptsApiID, ftThing, thingsApiUrl, 1)
 }
+
+// conversionWarnings lists what convertToPerson drops or converts in a way that may not be intended
+func conversionWarnings(concept Concept) []string {
+	var warnings []string
+	if !strings.Contains(concept.Type, "Person") {
+		warnings = append(warnings, fmt.Sprintf("concept type %s is not a person, the person would not be served", concept.Type))
+	}
+	for _, account := range concept.Account {
+		if _, ok := account.Value.(string); !ok {
+			warnings = append(warnings, fmt.Sprintf("account %s has a non-string value and is dropped", account.Type))
+			continue
+		}
+		if accountField(account.Type) == "" {
+			warnings = append(warnings, fmt.Sprintf("account of unsupported type %s is dropped", account.Type))
+		}
+	}
+	for _, label := range concept.AlternativeLabels {
+		if _, ok := label.Value.(string); !ok {
+			warnings = append(warnings, fmt.Sprintf("alternative label %s has a non-string value and is dropped", label.Type))
+		}
+	}
+	for _, related := range concept.RelatedConcepts {
+		if !strings.Contains(related.Concept.Type, "Membership") {
+			warnings = append(warnings, fmt.Sprintf("related concept %s of type %s is not a membership but is converted to one", related.Concept.ID, related.Concept.Type))
+			continue
+		}
+		hasOrganisation := false
+		for _, r := range related.Concept.RelatedConcepts {
+			if strings.Contains(r.Concept.Type, "Organisation") {
+				hasOrganisation = true
+			} else if !strings.Contains(r.Concept.Type, "Role") {
+				warnings = append(warnings, fmt.Sprintf("concept %s of type %s related to membership %s is dropped", r.Concept.ID, r.Concept.Type, related.Concept.ID))
+			}
+		}
+		if !hasOrganisation {
+			warnings = append(warnings, fmt.Sprintf("membership %s has no organisation", related.Concept.ID))
+		}
+	}
+	if len(concept.BroaderConcepts) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d broader concepts are dropped", len(concept.BroaderConcepts)))
+	}
+	if len(concept.NarrowerConcepts) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d narrower concepts are dropped", len(concept.NarrowerConcepts)))
+	}
+	if len(concept.ChangeEvents) > 0 {
+		warnings = append(warnings, "change events of the person are dropped")
+	}
+	return warnings
+}
@@ -0,0 +1,86 @@
+package people
+
+import (
+	"strings"
+
+	"github.com/Financial-Times/neo-model-utils-go/mapper"
+)
+
+func convertToPersonV2(concept Concept, p *PersonV2) {
+	p.ID = convertID(concept.ID)
+	p.APIURL = convertApiUrl(concept.APIURL, "people")
+	p.PrefLabel = concept.PrefLabel
+	p.Types = mapper.FullTypeHierarchy(concept.Type)
+	p.DirectType = concept.Type
+	p.Salutation = concept.Salutation
+	p.BirthYear = concept.BirthYear
+	p.Description = concept.Description
+	p.DescriptionXML = concept.DescriptionXML
+	p.ImageURL = concept.ImageURL
+	p.IsDeprecated = concept.IsDeprecated
+
+	var accounts AccountsV2
+	for _, account := range concept.Account {
+		value, _ := account.Value.(string)
+		switch accountField(account.Type) {
+		case "facebookProfile":
+			accounts.FacebookProfile = value
+		case "twitterHandle":
+			accounts.TwitterHandle = value
+		case "emailAddress":
+			accounts.EmailAddress = value
+		}
+	}
+	if accounts != (AccountsV2{}) {
+		p.Accounts = &accounts
+	}
+
+	for _, label := range concept.AlternativeLabels {
+		value, ok := label.Value.(string)
+		if !ok {
+			continue
+		}
+		p.AlternativeLabels = append(p.AlternativeLabels, AlternativeLabelV2{
+			Type:  label.Type,
+			Value: value,
+		})
+	}
+
+	for _, related := range concept.RelatedConcepts {
+		if !strings.Contains(related.Concept.Type, "Membership") {
+			continue
+		}
+		p.Memberships = append(p.Memberships, convertToMembershipV2(related.Concept))
+	}
+}
+
+func convertToMembershipV2(c Concept) MembershipV2 {
+	m := MembershipV2{
+		Title:        c.PrefLabel,
+		Types:        mapper.FullTypeHierarchy(c.Type),
+		DirectType:   c.Type,
+		ChangeEvents: c.ChangeEvents,
+	}
+	for _, related := range c.RelatedConcepts {
+		switch {
+		case m.Organisation == nil && strings.Contains(related.Concept.Type, "Organisation"):
+			m.Organisation = &OrganisationV2{
+				ID:         convertID(related.Concept.ID),
+				APIURL:     convertApiUrl(related.Concept.APIURL, "organisations"),
+				PrefLabel:  related.Concept.PrefLabel,
+				Types:      mapper.FullTypeHierarchy(related.Concept.Type),
+				DirectType: related.Concept.Type,
+			}
+		case strings.Contains(related.Concept.Type, "Role"):
+			m.Roles = append(m.Roles, RoleV2{
+				ID:           convertID(related.Concept.ID),
+				APIURL:       convertApiUrl(related.Concept.APIURL, "things"),
+				PrefLabel:    related.Concept.PrefLabel,
+				Types:        mapper.FullTypeHierarchy(related.Concept.Type),
+				DirectType:   related.Concept.Type,
+				ChangeEvents: related.Concept.ChangeEvents,
+			})
+		}
+	}
+	return m
+}
@@ -0,0 +1,166 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const anyOrigin = "*"
+
+// personPathRegexp matches the paths of the person routes registered by RegisterHandlers, the only ones preflight
+// requests are answered for
+var personPathRegexp = regexp.MustCompile(`^(/v[0-9]+)?/people/[^/]+$`)
+
+// CORSConfig configures cross-origin requests from browsers. Allowed origins are exact, such as
+// https://tools.ft.com, contain one wildcard, such as https://*.ft.com, or are * for any origin.
+// An allowed header of * allows any header.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	MaxAge           time.Duration
+	AllowCredentials bool
+}
+
+// ParseCORSOrigins parses comma separated allowed origins, checking they have at most one wildcard
+func ParseCORSOrigins(specs string) ([]string, error) {
+	var origins []string
+	for _, origin := range SplitList(specs) {
+		if origin != anyOrigin && strings.Count(origin, "*") > 1 {
+			return nil, fmt.Errorf("invalid origin %q, expected at most one wildcard", origin)
+		}
+		if origin != anyOrigin && !strings.Contains(origin, "://") {
+			return nil, fmt.Errorf("invalid origin %q, expected a scheme such as https://", origin)
+		}
+		origins = append(origins, strings.ToLower(strings.TrimSuffix(origin, "/")))
+	}
+	return origins, nil
+}
+
+// SplitList splits a comma separated list, such as an option value or a header, dropping empty items
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type CORS struct {
+	config CORSConfig
+}
+
+func NewCORS(config CORSConfig) *CORS {
+	return &CORS{config: config}
+}
+
+// Handler adds CORS headers to API responses for allowed origins, and answers preflight requests to the person routes
+// itself, as they only accept the methods they serve. Preflight requests to other paths get the usual response of
+// the router, such as a 404. Admin endpoints are not available cross-origin.
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdminPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		origin := r.Header.Get("Origin")
+		if r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != "" && personPathRegexp.MatchString(r.URL.Path) {
+			c.preflight(w, r, origin)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		if origin != "" && c.allowedOrigin(origin) {
+			c.allowOrigin(w, origin)
+			if len(c.config.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.config.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight allows the request when its origin, method and headers are all allowed. Otherwise it responds without
+// CORS headers, and the browser does not make the request.
+func (c *CORS) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	w.Header().Add("Vary", "Origin")
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	method := r.Header.Get("Access-Control-Request-Method")
+	headers := SplitList(r.Header.Get("Access-Control-Request-Headers"))
+	if !c.allowedOrigin(origin) || !c.allowedMethod(method) || !c.allowedHeaders(headers) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	c.allowOrigin(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(c.config.AllowedMethods, ", "))
+	if len(headers) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	}
+	if c.config.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.config.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowOrigin echoes the origin, unless any origin is allowed without credentials
+func (c *CORS) allowOrigin(w http.ResponseWriter, origin string) {
+	if containsString(c.config.AllowedOrigins, anyOrigin) && !c.config.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", anyOrigin)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if c.config.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *CORS) allowedOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.config.AllowedOrigins {
+		if allowed == anyOrigin || allowed == origin {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok &&
+			len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) allowedMethod(method string) bool {
+	for _, allowed := range c.config.AllowedMethods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) allowedHeaders(headers []string) bool {
+	for _, header := range headers {
+		if !c.allowedHeader(header) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CORS) allowedHeader(header string) bool {
+	for _, allowed := range c.config.AllowedHeaders {
+		if allowed == "*" || strings.EqualFold(allowed, header) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package people
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCORSTestRouter(config CORSConfig) http.Handler {
+	router := newTestRouter(time.Minute)
+	router.HandleFunc("/__gtg", func(w http.ResponseWriter, r *http.Request) {})
+	return NewCORS(config).Handler(router)
+}
+
+func preflight(handler http.Handler, path, origin, method, headers string) *httptest.ResponseRecorder {
+	req := newRequest(http.MethodOptions, path, "")
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+var corsTestConfig = CORSConfig{
+	AllowedOrigins: []string{"https://tools.ft.com", "https://*.editorial.ft.com"},
+	AllowedMethods: []string{"GET"},
+	AllowedHeaders: []string{"Accept", "X-Api-Key"},
+	ExposedHeaders: []string{"X-Request-Id"},
+	MaxAge:         10 * time.Minute,
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	rec := preflight(handler, "/people/"+authTestUUID, "https://tools.ft.com", "GET", "accept, x-api-key")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://tools.ft.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "accept, x-api-key", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rec.Header().Values("Vary"))
+
+	rec = preflight(handler, "/v2/people/"+authTestUUID, "https://desk.editorial.ft.com", "GET", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://desk.editorial.ft.com", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_PreflightRejected(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	for name, rec := range map[string]*httptest.ResponseRecorder{
+		"origin":          preflight(handler, "/people/"+authTestUUID, "https://evil.com", "GET", ""),
+		"wildcard origin": preflight(handler, "/people/"+authTestUUID, "https://editorial.ft.com.evil.com", "GET", ""),
+		"method":          preflight(handler, "/people/"+authTestUUID, "https://tools.ft.com", "DELETE", ""),
+		"header":          preflight(handler, "/people/"+authTestUUID, "https://tools.ft.com", "GET", "X-Custom"),
+	} {
+		assert.Equal(t, http.StatusNoContent, rec.Code, name)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), name)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"), name)
+	}
+}
+
+func TestCORS_PreflightToUnknownPaths(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	for _, path := range []string{"/unknown", "/people", "/people/" + authTestUUID + "/memberships", "/vx/people/" + authTestUUID} {
+		rec := preflight(handler, path, "https://tools.ft.com", "GET", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"), path)
+	}
+}
+
+func TestCORS_PlainOptionsIsNotPreflight(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodOptions, "/people/"+authTestUUID, ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "GET, HEAD, OPTIONS", rec.Header().Get("Allow"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	req := newRequest("GET", "/people/invalid", "")
+	req.Header.Set("Origin", "https://tools.ft.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "https://tools.ft.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Request-Id", rec.Header().Get("Access-Control-Expose-Headers"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+
+	req = newRequest("GET", "/people/invalid", "")
+	req.Header.Set("Origin", "https://evil.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Origin", "responses vary by origin even when it is not allowed")
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	config := corsTestConfig
+	config.AllowedOrigins = []string{"*"}
+	rec := preflight(newCORSTestRouter(config), "/people/"+authTestUUID, "https://anywhere.com", "GET", "")
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	config.AllowedHeaders = []string{"*"}
+	config.AllowCredentials = true
+	rec = preflight(newCORSTestRouter(config), "/people/"+authTestUUID, "https://anywhere.com", "GET", "X-Custom")
+	assert.Equal(t, "https://anywhere.com", rec.Header().Get("Access-Control-Allow-Origin"), "credentials require the origin to be echoed")
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "X-Custom", rec.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestCORS_SkipsAdminEndpoints(t *testing.T) {
+	handler := newCORSTestRouter(corsTestConfig)
+
+	req := newRequest("GET", "/__gtg", "")
+	req.Header.Set("Origin", "https://tools.ft.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Values("Vary"))
+}
+
+func TestParseCORSOrigins(t *testing.T) {
+	origins, err := ParseCORSOrigins("https://Tools.ft.com/, https://*.ft.com, *")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://tools.ft.com", "https://*.ft.com", "*"}, origins)
+
+	_, err = ParseCORSOrigins("https://*.*.ft.com")
+	assert.EqualError(t, err, `invalid origin "https://*.*.ft.com", expected at most one wildcard`)
+	_, err = ParseCORSOrigins("tools.ft.com")
+	assert.EqualError(t, err, `invalid origin "tools.ft.com", expected a scheme such as https://`)
+
+	origins, err = ParseCORSOrigins("")
+	require.NoError(t, err)
+	assert.Empty(t, origins)
+}
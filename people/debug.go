@@ -0,0 +1,138 @@
+package people
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+const (
+	debugPath = "/__debug/people/{uuid}"
+
+	unauthorisedMsg = "A valid debug token is required"
+
+	redactedConceptWarning   = "upstream concept is omitted because the person is redacted"
+	canonicalNotFoundWarning = "canonical person %s is not found"
+)
+
+// DebugPerson shows how a person is derived from public-concepts-api. ConcordanceChain holds the UUIDs followed from
+// a concorded UUID to its canonical person.
+type DebugPerson struct {
+	Upstream         DebugUpstream   `json:"upstream"`
+	Concept          json.RawMessage `json:"concept,omitempty"`
+	Person           *Person         `json:"person,omitempty"`
+	CanonicalUUID    string          `json:"canonicalUuid,omitempty"`
+	ConcordanceChain []string        `json:"concordanceChain,omitempty"`
+	Warnings         []string        `json:"warnings"`
+}
+
+// DebugUpstream describes the request to public-concepts-api
+type DebugUpstream struct {
+	URL        string  `json:"url"`
+	Status     int     `json:"status,omitempty"`
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// RegisterDebugHandlers exposes /__debug/people/{uuid} to callers presenting the debug token as a bearer token.
+// Nothing is registered when no token is configured.
+func (h *Handler) RegisterDebugHandlers(router *mux.Router) {
+	if h.debugToken == "" {
+		return
+	}
+	logger.Info("Registering debug handlers")
+	router.Handle(debugPath, handlers.MethodHandler{
+		"GET": http.HandlerFunc(h.GetPersonDebug),
+	})
+}
+
+// GetPersonDebug returns the raw upstream concept next to the converted person
+func (h *Handler) GetPersonDebug(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	transId := transactionidutils.GetTransactionIDFromRequest(r)
+	w.Header().Set("X-Request-Id", transId)
+	w.Header().Set("Cache-Control", "no-store")
+
+	if !h.validDebugToken(r) {
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Warn(unauthorisedMsg)
+		writeJSONStatus(w, unauthorisedMsg, http.StatusUnauthorized)
+		return
+	}
+	if !IsValidUUID(uuid) {
+		writeJSONStatus(w, badRequestMsg, http.StatusBadRequest)
+		return
+	}
+
+	debug := DebugPerson{Warnings: []string{}}
+	upstream, err := h.fetchConcept(transactionidutils.TransactionAwareContext(r.Context(), transId), uuid)
+	debug.Upstream = DebugUpstream{
+		URL:        upstream.URL,
+		Status:     upstream.Status,
+		DurationMs: durationMillis(upstream.Duration),
+	}
+	if err != nil {
+		debug.Upstream.Error = err.Error()
+	}
+
+	if len(upstream.Body) > 0 {
+		var concept Concept
+		if json.Valid(upstream.Body) {
+			debug.Concept = upstream.Body
+		}
+		if err := json.Unmarshal(upstream.Body, &concept); err != nil {
+			debug.Warnings = append(debug.Warnings, "upstream response is not a concept: "+logSafeJSONError(err).Error())
+		} else if upstream.Status == http.StatusOK {
+			var redacted bool
+			if concept, redacted = h.redactConcept(r.Context(), []string{uuid}, concept); redacted {
+				debug.Concept = nil
+				debug.Warnings = append(debug.Warnings, redactedConceptWarning)
+			}
+			var p Person
+			convertToPerson(concept, &p)
+			debug.Person = &p
+			debug.Warnings = append(debug.Warnings, conversionWarnings(concept)...)
+			if strings.TrimPrefix(p.ID, urlPrefix) != uuid {
+				h.debugConcordance(r.Context(), &debug, uuid, transId)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", contentTypeJson)
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(debug); err != nil {
+		logger.WithError(err).WithTransactionID(transId).Warn("Debug response could not be written")
+	}
+}
+
+// debugConcordance follows the concordance chain of a concorded UUID the same way person requests do
+func (h *Handler) debugConcordance(ctx context.Context, debug *DebugPerson, uuid, tid string) {
+	_, chain, found, err := h.resolvePersonConcept(ctx, uuid, tid)
+	debug.ConcordanceChain = chain
+	switch {
+	case err != nil:
+		debug.Warnings = append(debug.Warnings, err.Error())
+	case !found:
+		debug.Warnings = append(debug.Warnings, fmt.Sprintf(canonicalNotFoundWarning, chain[len(chain)-1]))
+	default:
+		debug.CanonicalUUID = chain[len(chain)-1]
+	}
+}
+
+func (h *Handler) validDebugToken(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(auth, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.debugToken)) == 1
+}
@@ -0,0 +1,150 @@
+package people
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+type DebugHandlerTestSuite struct {
+	suite.Suite
+	router *mux.Router
+}
+
+func (suite *DebugHandlerTestSuite) SetupTest() {
+	logger.InitDefaultLogger("debug-test")
+	suite.router = mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient, WithDebugToken("secret")).RegisterDebugHandlers(suite.router)
+	httpmock.Activate()
+}
+
+func (suite *DebugHandlerTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+}
+
+func (suite *DebugHandlerTestSuite) get(uuid, token string) *httptest.ResponseRecorder {
+	req := newRequest("GET", "/__debug/people/"+uuid, "")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson() {
+	uuid := "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	upstream := `{
+		"id": "http://www.ft.com/thing/2d3e16e0-61cb-4322-8aff-3b01c59f4daa",
+		"apiUrl": "http://api.ft.com/people/2d3e16e0-61cb-4322-8aff-3b01c59f4daa",
+		"prefLabel": "Someone",
+		"type": "http://www.ft.com/ontology/person/Person",
+		"account": [
+			{"type": "http://www.ft.com/ontology/twitterHandle", "value": "@someone"},
+			{"type": "http://www.ft.com/ontology/linkedinProfile", "value": "someone"}
+		],
+		"relatedConcepts": [
+			{"concept": {"id": "http://www.ft.com/thing/c89c1b9e-2bc5-3dbd-bcc5-595d2dabb4bd", "type": "http://www.ft.com/ontology/Topic"}, "predicate": "http://www.ft.com/ontology/related"}
+		]
+	}`
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, upstream))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/2d3e16e0-61cb-4322-8aff-3b01c59f4daa", httpmock.NewStringResponder(200, upstream))
+
+	rec := suite.get(uuid, "secret")
+
+	var debug DebugPerson
+	suite.Require().NoError(json.NewDecoder(rec.Body).Decode(&debug))
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Equal("http://localhost:8080/concepts/"+uuid+"?showRelationship=related", debug.Upstream.URL)
+	suite.Equal(http.StatusOK, debug.Upstream.Status)
+	suite.JSONEq(upstream, string(debug.Concept))
+	suite.Require().NotNil(debug.Person)
+	suite.Equal("Someone", debug.Person.PrefLabel)
+	suite.Equal("2d3e16e0-61cb-4322-8aff-3b01c59f4daa", debug.CanonicalUUID)
+	suite.Equal([]string{uuid, "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"}, debug.ConcordanceChain)
+	suite.Equal("@someone", debug.Person.TwitterHandle)
+	suite.Equal([]string{
+		"account of unsupported type http://www.ft.com/ontology/linkedinProfile is dropped",
+		"related concept http://www.ft.com/thing/c89c1b9e-2bc5-3dbd-bcc5-595d2dabb4bd of type http://www.ft.com/ontology/Topic is not a membership but is converted to one",
+	}, debug.Warnings)
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson_ConcordanceChain() {
+	registerConcordance(concordedUUID, intermediateUUID, finalUUID)
+
+	var debug DebugPerson
+	suite.Require().NoError(json.NewDecoder(suite.get(concordedUUID, "secret").Body).Decode(&debug))
+	suite.Equal(finalUUID, debug.CanonicalUUID)
+	suite.Equal([]string{concordedUUID, intermediateUUID, finalUUID}, debug.ConcordanceChain)
+	suite.Equal("http://api.ft.com/things/"+intermediateUUID, debug.Person.ID, "the person should be converted from the first upstream response")
+	suite.Empty(debug.Warnings)
+
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+intermediateUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, concordedUUID, concordedUUID, "")))
+	debug = DebugPerson{}
+	suite.Require().NoError(json.NewDecoder(suite.get(concordedUUID, "secret").Body).Decode(&debug))
+	suite.Empty(debug.CanonicalUUID)
+	suite.Equal([]string{concordedUUID, intermediateUUID}, debug.ConcordanceChain)
+	suite.Equal([]string{"concordance chain loops: " + concordedUUID + " -> " + intermediateUUID + " -> " + concordedUUID}, debug.Warnings)
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson_Redacted() {
+	uuid := "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+	policy, err := ParseRedactionPolicy([]byte(`{"rules": [{"people": ["` + uuid + `"], "fields": ["prefLabel"]}]}`))
+	suite.Require().NoError(err)
+	suite.router = mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient, WithDebugToken("secret"), WithRedactionPolicy(policy)).RegisterDebugHandlers(suite.router)
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, `{
+		"id": "http://www.ft.com/thing/2d3e16e0-61cb-4322-8aff-3b01c59f4daa",
+		"prefLabel": "Someone",
+		"type": "http://www.ft.com/ontology/person/Person"
+	}`))
+
+	rec := suite.get(uuid, "secret")
+
+	suite.NotContains(rec.Body.String(), "Someone")
+	var debug DebugPerson
+	suite.Require().NoError(json.NewDecoder(rec.Body).Decode(&debug))
+	suite.Nil(debug.Concept)
+	suite.Require().NotNil(debug.Person)
+	suite.Empty(debug.Person.PrefLabel)
+	suite.Contains(debug.Warnings, redactedConceptWarning)
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson_UpstreamNotFound() {
+	uuid := "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(404, `{"message":"Concept not found"}`))
+
+	rec := suite.get(uuid, "secret")
+
+	var debug DebugPerson
+	suite.Require().NoError(json.NewDecoder(rec.Body).Decode(&debug))
+	suite.Equal(http.StatusNotFound, debug.Upstream.Status)
+	suite.Nil(debug.Person)
+	suite.JSONEq(`{"message":"Concept not found"}`, string(debug.Concept))
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson_Unauthorised() {
+	suite.Equal(http.StatusUnauthorized, suite.get("2d3e16e0-61cb-4322-8aff-3b01c59f4daa", "").Code)
+	suite.Equal(http.StatusUnauthorized, suite.get("2d3e16e0-61cb-4322-8aff-3b01c59f4daa", "wrong").Code)
+}
+
+func (suite *DebugHandlerTestSuite) TestDebugPerson_DisabledWithoutToken() {
+	router := mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient).RegisterDebugHandlers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/__debug/people/2d3e16e0-61cb-4322-8aff-3b01c59f4daa", ""))
+	suite.Equal(http.StatusNotFound, rec.Code)
+}
+
+func TestDebugHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DebugHandlerTestSuite))
+}
@@ -0,0 +1,86 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	deprecatedParam = "deprecated"
+
+	unknownDeprecatedPolicyMsg = "Unknown deprecated policy %s, expected serve, hide, gone or successor"
+	goneMsg                    = "Person %s is deprecated"
+	supersededPerson           = "Person %s is deprecated and superseded by %s; serving redirect"
+	servingSuccessorPerson     = "Person %s is deprecated and superseded by %s; serving successor"
+)
+
+// DeprecatedPolicy is how requests for deprecated people are answered
+type DeprecatedPolicy string
+
+const (
+	// DeprecatedServe serves deprecated people, with a Deprecation header and a Sunset header when configured
+	DeprecatedServe DeprecatedPolicy = "serve"
+	// DeprecatedHide answers requests for deprecated people with a 404, as if they did not exist
+	DeprecatedHide DeprecatedPolicy = "hide"
+	// DeprecatedGone answers requests for deprecated people with a 410
+	DeprecatedGone DeprecatedPolicy = "gone"
+	// DeprecatedSuccessor redirects to the person superseding a deprecated one, or serves it when there is none
+	DeprecatedSuccessor DeprecatedPolicy = "successor"
+)
+
+func ParseDeprecatedPolicy(policy string) (DeprecatedPolicy, error) {
+	switch p := DeprecatedPolicy(policy); p {
+	case DeprecatedServe, DeprecatedHide, DeprecatedGone, DeprecatedSuccessor:
+		return p, nil
+	}
+	return "", fmt.Errorf(unknownDeprecatedPolicyMsg, policy)
+}
+
+// WithDeprecatedPolicy sets how requests for deprecated people are answered when the request does not choose
+func WithDeprecatedPolicy(policy DeprecatedPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.deprecated = policy
+	}
+}
+
+// WithDeprecatedSunset sets the date deprecated people stop being served, announced in the Sunset header
+func WithDeprecatedSunset(sunset time.Time) HandlerOption {
+	return func(h *Handler) {
+		h.sunset = sunset
+	}
+}
+
+// deprecatedPolicy returns the policy requested in the query, or the configured one
+func (h *Handler) deprecatedPolicy(r *http.Request) (DeprecatedPolicy, error) {
+	if requested := r.URL.Query().Get(deprecatedParam); requested != "" {
+		return ParseDeprecatedPolicy(requested)
+	}
+	return h.configuredDeprecatedPolicy(), nil
+}
+
+// configuredDeprecatedPolicy returns the policy set by WithDeprecatedPolicy, or DeprecatedServe
+func (h *Handler) configuredDeprecatedPolicy() DeprecatedPolicy {
+	if h.deprecated == "" {
+		return DeprecatedServe
+	}
+	return h.deprecated
+}
+
+// successor is the first valid UUID superseding the concept, if any
+func successor(c Concept) (string, bool) {
+	for _, uuid := range c.SupersededByUUIDs {
+		if IsValidUUID(uuid) {
+			return uuid, true
+		}
+	}
+	return "", false
+}
+
+// setDeprecationHeaders marks the response as being for a deprecated person
+func (h *Handler) setDeprecationHeaders(w http.ResponseWriter) {
+	w.Header().Set("Deprecation", "true")
+	if !h.sunset.IsZero() {
+		w.Header().Set("Sunset", h.sunset.UTC().Format(http.TimeFormat))
+	}
+}
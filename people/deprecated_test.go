@@ -0,0 +1,175 @@
+package people
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/people/peoplepb"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+const (
+	deprecatedUUID = "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"
+	successorUUID  = "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+)
+
+func registerDeprecatedPerson(extra string) {
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+deprecatedUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, deprecatedUUID, deprecatedUUID, `"isDeprecated":true,`+extra)))
+}
+
+func TestDeprecatedPolicies(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerDeprecatedPerson(`"supersededByUUIDs":["` + successorUUID + `"],`)
+	sunset := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+	router := newTestRouter(0, WithDeprecatedSunset(sunset))
+
+	tests := map[string]struct {
+		status   int
+		location string
+	}{
+		"":          {status: http.StatusOK},
+		"serve":     {status: http.StatusOK},
+		"hide":      {status: http.StatusNotFound},
+		"gone":      {status: http.StatusGone},
+		"successor": {status: http.StatusMovedPermanently, location: "/people/" + successorUUID + "?deprecated=successor"},
+	}
+	for policy, expected := range tests {
+		path := "/people/" + deprecatedUUID
+		if policy != "" {
+			path += "?deprecated=" + policy
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newRequest("GET", path, ""))
+		assert.Equal(t, expected.status, rec.Code, policy)
+		assert.Equal(t, expected.location, rec.Header().Get("Location"), policy)
+		if expected.status == http.StatusOK || expected.location != "" {
+			assert.Equal(t, "true", rec.Header().Get("Deprecation"), policy)
+			assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", rec.Header().Get("Sunset"), policy)
+		} else {
+			assert.Empty(t, rec.Header().Get("Deprecation"), policy)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+deprecatedUUID+"?deprecated=never", ""))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, fmt.Sprintf(`{"message":"%s"}`, fmt.Sprintf(unknownDeprecatedPolicyMsg, "never")), rec.Body.String())
+
+	body := scrapeMetrics(router)
+	assert.Contains(t, body, `public_people_api_deprecated_person_requests_total{policy="serve"} 2`)
+	assert.Contains(t, body, `public_people_api_deprecated_person_requests_total{policy="hide"} 1`)
+	assert.Contains(t, body, `public_people_api_deprecated_person_requests_total{policy="gone"} 1`)
+	assert.Contains(t, body, `public_people_api_deprecated_person_requests_total{policy="successor"} 1`)
+}
+
+func TestDeprecatedPolicies_GRPC(t *testing.T) {
+	logger.InitDefaultLogger("deprecated-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerDeprecatedPerson(`"supersededByUUIDs":["` + successorUUID + `"],`)
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+successorUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, successorUUID, successorUUID, "")))
+
+	tests := map[DeprecatedPolicy]struct {
+		status    peoplepb.PersonResult_Status
+		person    string
+		canonical string
+	}{
+		DeprecatedServe:     {status: peoplepb.PersonResult_STATUS_OK, person: deprecatedUUID},
+		DeprecatedHide:      {status: peoplepb.PersonResult_STATUS_NOT_FOUND},
+		DeprecatedGone:      {status: peoplepb.PersonResult_STATUS_NOT_FOUND},
+		DeprecatedSuccessor: {status: peoplepb.PersonResult_STATUS_OK, person: successorUUID, canonical: successorUUID},
+	}
+	for policy, expected := range tests {
+		metrics := NewMetrics()
+		client := newGRPCTestClient(t, NewHandler(0, "http://localhost:8080", http.DefaultClient, WithDeprecatedPolicy(policy), WithMetrics(metrics)))
+
+		person, err := client.GetPerson(context.Background(), &peoplepb.GetPersonRequest{Uuid: deprecatedUUID})
+		if expected.status == peoplepb.PersonResult_STATUS_NOT_FOUND {
+			assert.Equal(t, codes.NotFound, status.Code(err), policy)
+		} else {
+			require.NoError(t, err, policy)
+			assert.Equal(t, "http://api.ft.com/things/"+expected.person, person.GetId(), policy)
+		}
+
+		batch, err := client.BatchGetPeople(context.Background(), &peoplepb.BatchGetPeopleRequest{Uuids: []string{deprecatedUUID}})
+		require.NoError(t, err, policy)
+		require.Len(t, batch.GetResults(), 1, policy)
+		assert.Equal(t, expected.status, batch.GetResults()[0].GetStatus(), policy)
+		assert.Equal(t, expected.canonical, batch.GetResults()[0].GetCanonicalUuid(), policy)
+
+		stream, err := client.StreamPeople(context.Background(), &peoplepb.StreamPeopleRequest{Uuids: []string{deprecatedUUID}})
+		require.NoError(t, err, policy)
+		result, err := stream.Recv()
+		require.NoError(t, err, policy)
+		assert.Equal(t, expected.status, result.GetStatus(), policy)
+		assert.Equal(t, expected.canonical, result.GetCanonicalUuid(), policy)
+
+		router := mux.NewRouter()
+		metrics.RegisterHandlers(router)
+		assert.Contains(t, scrapeMetrics(router), `public_people_api_deprecated_person_requests_total{policy="`+string(policy)+`"} 3`, policy)
+	}
+}
+
+func TestDeprecatedPolicy_Configured(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerDeprecatedPerson("")
+	router := newTestRouter(0, WithDeprecatedPolicy(DeprecatedGone))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+deprecatedUUID, ""))
+	assert.Equal(t, http.StatusGone, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+deprecatedUUID+"?deprecated=serve", ""))
+	assert.Equal(t, http.StatusOK, rec.Code, "requests can choose another policy")
+	assert.Empty(t, rec.Header().Get("Sunset"))
+}
+
+func TestDeprecatedPolicy_SuccessorWithoutSuccessor(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerDeprecatedPerson(`"supersededByUUIDs":["BOO"],`)
+	router := newTestRouter(0, WithDeprecatedPolicy(DeprecatedSuccessor))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+deprecatedUUID, ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+}
+
+func TestDeprecatedPolicy_NotDeprecated(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+successorUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, successorUUID, successorUUID, "")))
+	router := newTestRouter(0, WithDeprecatedPolicy(DeprecatedGone))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+successorUUID, ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+	assert.NotContains(t, scrapeMetrics(router), "public_people_api_deprecated_person_requests_total{")
+}
+
+func TestParseDeprecatedPolicy(t *testing.T) {
+	policy, err := ParseDeprecatedPolicy("successor")
+	require.NoError(t, err)
+	assert.Equal(t, DeprecatedSuccessor, policy)
+
+	_, err = ParseDeprecatedPolicy("")
+	assert.EqualError(t, err, fmt.Sprintf(unknownDeprecatedPolicyMsg, ""))
+}
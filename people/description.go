@@ -0,0 +1,214 @@
+package people
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	descriptionFormatParam = "descriptionFormat"
+
+	descriptionFormatXML  = "xml"
+	descriptionFormatHTML = "html"
+	descriptionFormatText = "text"
+
+	unknownDescriptionFormatMsg = "Unknown description format %s, expected xml, html or text"
+
+	conceptLinkPrefix = "https://www.ft.com/stream/"
+	contentLinkPrefix = "https://www.ft.com/content/"
+)
+
+var (
+	// allowedElements maps the body XML elements that are kept in HTML output to the HTML element they become
+	allowedElements = map[string]string{
+		"p":          "p",
+		"br":         "br",
+		"strong":     "strong",
+		"b":          "strong",
+		"em":         "em",
+		"i":          "em",
+		"ul":         "ul",
+		"ol":         "ol",
+		"li":         "li",
+		"h2":         "h2",
+		"h3":         "h3",
+		"h4":         "h4",
+		"blockquote": "blockquote",
+		"sub":        "sub",
+		"sup":        "sup",
+		"a":          "a",
+		"ft-concept": "a",
+		"ft-content": "a",
+	}
+	// droppedElements are removed from the output together with everything inside them
+	droppedElements = map[string]bool{
+		"script": true,
+		"style":  true,
+		"iframe": true,
+		"object": true,
+	}
+	// blockElements end a line in text output
+	blockElements = map[string]bool{
+		"p":          true,
+		"br":         true,
+		"li":         true,
+		"h2":         true,
+		"h3":         true,
+		"h4":         true,
+		"blockquote": true,
+	}
+
+	conceptLinkRegexp = regexp.MustCompile(`^https?://(?:api\.ft\.com/(?:things|people|organisations|concepts|brands)|www\.ft\.com/thing)/` + validUUID)
+	contentLinkRegexp = regexp.MustCompile(`^https?://(?:api\.ft\.com|www\.ft\.com)/content/` + validUUID)
+	safeLinkRegexp    = regexp.MustCompile(`^(?i)(https?:|mailto:)`)
+	tagRegexp         = regexp.MustCompile(`<[^>]*>`)
+	blankLinesRegexp  = regexp.MustCompile(`\n{3,}`)
+)
+
+func validDescriptionFormat(format string) bool {
+	switch format {
+	case "", descriptionFormatXML, descriptionFormatHTML, descriptionFormatText:
+		return true
+	}
+	return false
+}
+
+// renderDescriptionHTML converts UPP body XML into sanitized HTML. Only allow-listed elements are kept,
+// links to ft.com concepts and content are rewritten to their ft.com pages and all other attributes are dropped.
+func renderDescriptionHTML(bodyXML string) (string, error) {
+	var out bytes.Buffer
+	var open []string
+	err := walkBodyXML(bodyXML, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name, ok := allowedElements[t.Name.Local]
+			if !ok {
+				open = append(open, "")
+				return
+			}
+			open = append(open, name)
+			if name == "br" {
+				out.WriteString("<br>")
+				return
+			}
+			out.WriteString("<" + name)
+			if name == "a" {
+				if href := linkTarget(t); href != "" {
+					out.WriteString(` href="` + html.EscapeString(href) + `"`)
+				}
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			if len(open) == 0 {
+				return
+			}
+			name := open[len(open)-1]
+			open = open[:len(open)-1]
+			if name != "" && name != "br" {
+				out.WriteString("</" + name + ">")
+			}
+		case xml.CharData:
+			out.WriteString(html.EscapeString(string(t)))
+		}
+	})
+	if err != nil {
+		return html.EscapeString(stripTags(bodyXML)), err
+	}
+	return out.String(), nil
+}
+
+// renderDescriptionText converts UPP body XML into plain text, with block elements on their own lines
+func renderDescriptionText(bodyXML string) (string, error) {
+	var out strings.Builder
+	err := walkBodyXML(bodyXML, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "br" {
+				out.WriteString("\n")
+			}
+		case xml.EndElement:
+			if blockElements[t.Name.Local] && t.Name.Local != "br" {
+				out.WriteString("\n\n")
+			}
+		case xml.CharData:
+			out.Write(t)
+		}
+	})
+	if err != nil {
+		return strings.TrimSpace(stripTags(bodyXML)), err
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(out.String(), "\n\n")), nil
+}
+
+// walkBodyXML calls fn for every token of a body XML fragment, skipping dropped elements.
+// The fragment does not need a single root element, and HTML entities and unclosed tags are tolerated.
+func walkBodyXML(bodyXML string, fn func(xml.Token)) error {
+	d := xml.NewDecoder(strings.NewReader("<body>" + bodyXML + "</body>"))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	depth, dropping := 0, 0
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("malformed description XML: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if dropping > 0 || droppedElements[t.Name.Local] {
+				dropping++
+				continue
+			}
+			if depth == 1 {
+				continue
+			}
+		case xml.EndElement:
+			depth--
+			if dropping > 0 {
+				dropping--
+				continue
+			}
+			if depth == 0 {
+				continue
+			}
+		default:
+			if dropping > 0 {
+				continue
+			}
+		}
+		fn(tok)
+	}
+}
+
+func linkTarget(e xml.StartElement) string {
+	var target string
+	for _, attr := range e.Attr {
+		if (e.Name.Local == "a" && attr.Name.Local == "href") || (e.Name.Local != "a" && attr.Name.Local == "url") {
+			target = strings.TrimSpace(attr.Value)
+		}
+	}
+	if m := conceptLinkRegexp.FindStringSubmatch(target); m != nil {
+		return conceptLinkPrefix + m[1]
+	}
+	if m := contentLinkRegexp.FindStringSubmatch(target); m != nil {
+		return contentLinkPrefix + m[1]
+	}
+	if safeLinkRegexp.MatchString(target) {
+		return target
+	}
+	return ""
+}
+
+func stripTags(s string) string {
+	return html.UnescapeString(tagRegexp.ReplaceAllString(s, ""))
+}
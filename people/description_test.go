@@ -0,0 +1,76 @@
+package people
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDescriptionHTML(t *testing.T) {
+	tests := map[string]struct {
+		bodyXML string
+		html    string
+	}{
+		"allowed elements": {
+			bodyXML: `<p>Neil <strong>Cole</strong> is <em>CEO</em></p><ul><li>one</li></ul>`,
+			html:    `<p>Neil <strong>Cole</strong> is <em>CEO</em></p><ul><li>one</li></ul>`,
+		},
+		"mapped elements and attributes dropped": {
+			bodyXML: `<p class="x" onclick="evil()"><b>bold</b> <i>italic</i><br/>next</p>`,
+			html:    `<p><strong>bold</strong> <em>italic</em><br>next</p>`,
+		},
+		"disallowed elements keep their text": {
+			bodyXML: `<p><span>text</span><pull-quote>quote</pull-quote></p>`,
+			html:    `<p>textquote</p>`,
+		},
+		"dropped elements lose their content": {
+			bodyXML: `<p>before<script>alert("x")</script>after</p>`,
+			html:    `<p>beforeafter</p>`,
+		},
+		"concept links": {
+			bodyXML: `<ft-concept url="http://api.ft.com/people/60e54253-1e94-38df-83b1-a39804d1ac18" type="http://www.ft.com/ontology/person/Person">Neil</ft-concept>`,
+			html:    `<a href="https://www.ft.com/stream/60e54253-1e94-38df-83b1-a39804d1ac18">Neil</a>`,
+		},
+		"content links": {
+			bodyXML: `<ft-content url="http://api.ft.com/content/2d3e16e0-61cb-4322-8aff-3b01c59f4daa">story</ft-content>`,
+			html:    `<a href="https://www.ft.com/content/2d3e16e0-61cb-4322-8aff-3b01c59f4daa">story</a>`,
+		},
+		"unsafe links": {
+			bodyXML: `<a href="javascript:alert(1)">click</a> <a href="https://example.com/?a=1&amp;b=2">ok</a>`,
+			html:    `<a>click</a> <a href="https://example.com/?a=1&amp;b=2">ok</a>`,
+		},
+		"entities and unclosed tags": {
+			bodyXML: `<p>Caf&eacute; &amp; <strong>bar`,
+			html:    `<p>Café &amp; <strong>bar</strong></p>`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			html, err := renderDescriptionHTML(test.bodyXML)
+			assert.NoError(t, err)
+			assert.Equal(t, test.html, html)
+		})
+	}
+}
+
+func TestRenderDescriptionText(t *testing.T) {
+	text, err := renderDescriptionText(`<p>Neil <strong>Cole</strong></p><p>line<br/>break</p><script>x</script><ul><li>one</li><li>two</li></ul>`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Neil Cole\n\nline\nbreak\n\none\n\ntwo", text)
+}
+
+func TestRenderDescription_MalformedXML(t *testing.T) {
+	bodyXML := `<p>Neil <a href="x>Cole</p><`
+
+	html, err := renderDescriptionHTML(bodyXML)
+	assert.Error(t, err)
+	assert.NotContains(t, html, "<a")
+	assert.Contains(t, html, "Neil")
+
+	text, err := renderDescriptionText(bodyXML)
+	assert.Error(t, err)
+	assert.Contains(t, text, "Neil")
+	assert.NotContains(t, text, "<p>")
+}
@@ -0,0 +1,248 @@
+package people
+
+import (
+	"context"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/people/peoplepb"
+	"github.com/Financial-Times/transactionid-utils-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	// registers the gzip compressor, so that clients can request compressed batches and streams
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+// GRPCServer serves people over gRPC, using the same lookups as the HTTP Handler
+type GRPCServer struct {
+	peoplepb.UnimplementedPeopleServiceServer
+	handler *Handler
+}
+
+func NewGRPCServer(h *Handler) *GRPCServer {
+	return &GRPCServer{handler: h}
+}
+
+func (s *GRPCServer) Register(server *grpc.Server) {
+	logger.Info("Registering gRPC services")
+	peoplepb.RegisterPeopleServiceServer(server, s)
+}
+
+// GetPerson returns the person for a UUID, following concordance to the canonical person
+func (s *GRPCServer) GetPerson(ctx context.Context, req *peoplepb.GetPersonRequest) (*peoplepb.Person, error) {
+	tid := transactionIDFromContext(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, tid, "cache-control", s.handler.responseCacheControl(ctx)))
+
+	result := s.lookup(ctx, req.GetUuid(), tid)
+	switch result.Status {
+	case peoplepb.PersonResult_STATUS_OK:
+		return result.Person, nil
+	case peoplepb.PersonResult_STATUS_INVALID_UUID:
+		return nil, status.Error(codes.InvalidArgument, badRequestMsg)
+	case peoplepb.PersonResult_STATUS_NOT_FOUND:
+		return nil, status.Error(codes.NotFound, personNotFoundMsg)
+	default:
+		return nil, status.Error(codes.Internal, personUnableToBeRetrieved)
+	}
+}
+
+// BatchGetPeople looks up every requested UUID and returns the results in request order
+func (s *GRPCServer) BatchGetPeople(ctx context.Context, req *peoplepb.BatchGetPeopleRequest) (*peoplepb.BatchGetPeopleResponse, error) {
+	tid := transactionIDFromContext(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, tid, "cache-control", s.handler.responseCacheControl(ctx)))
+
+	resp := &peoplepb.BatchGetPeopleResponse{}
+	for _, uuid := range req.GetUuids() {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		resp.Results = append(resp.Results, s.lookup(ctx, uuid, tid))
+	}
+	return resp, nil
+}
+
+// StreamPeople sends a result for every requested UUID as each lookup completes
+func (s *GRPCServer) StreamPeople(req *peoplepb.StreamPeopleRequest, stream peoplepb.PeopleService_StreamPeopleServer) error {
+	tid := transactionIDFromContext(stream.Context())
+	stream.SetHeader(metadata.Pairs(requestIDMetadataKey, tid, "cache-control", s.handler.responseCacheControl(stream.Context())))
+
+	for _, uuid := range req.GetUuids() {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(s.lookup(stream.Context(), uuid, tid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GRPCServer) lookup(ctx context.Context, uuid, tid string) *peoplepb.PersonResult {
+	result := &peoplepb.PersonResult{Uuid: uuid}
+	if !IsValidUUID(uuid) {
+		logger.WithTransactionID(tid).WithField("UUID", uuid).Error(badRequestMsg)
+		result.Status = peoplepb.PersonResult_STATUS_INVALID_UUID
+		result.Message = badRequestMsg
+		return result
+	}
+
+	concept, chain, found, err := s.handler.resolvePersonConcept(ctx, uuid, tid)
+	if err == nil && found && concept.IsDeprecated {
+		concept, chain, found, err = s.deprecated(ctx, concept, chain, tid)
+	}
+	if err != nil {
+		result.Status = peoplepb.PersonResult_STATUS_ERROR
+		result.Message = personUnableToBeRetrieved
+		return result
+	}
+	if !found {
+		result.Status = peoplepb.PersonResult_STATUS_NOT_FOUND
+		result.Message = personNotFoundMsg
+		return result
+	}
+
+	canonicalId := chain[len(chain)-1]
+	if canonicalId != uuid {
+		logger.WithTransactionID(tid).WithField("UUID", uuid).Infof(servingCanonicalPerson, uuid, canonicalId)
+		result.CanonicalUuid = canonicalId
+	}
+	person := s.handler.convertPerson(ctx, concept)
+	if err := renderDescriptions(&person); err != nil {
+		logger.WithError(err).WithTransactionID(tid).WithUUID(uuid).Warn("Description could not be fully rendered")
+	}
+	result.Status = peoplepb.PersonResult_STATUS_OK
+	result.Person = toProtoPerson(person)
+	return result
+}
+
+// renderDescriptions renders the description XML of a person both as HTML and as text, as gRPC requests cannot
+// choose a description format. On malformed XML the best-effort renderings are kept and the first error returned.
+func renderDescriptions(p *Person) error {
+	if p.DescriptionXML == "" {
+		return nil
+	}
+	var htmlErr, textErr error
+	p.DescriptionHTML, htmlErr = renderDescriptionHTML(p.DescriptionXML)
+	p.DescriptionText, textErr = renderDescriptionText(p.DescriptionXML)
+	if htmlErr != nil {
+		return htmlErr
+	}
+	return textErr
+}
+
+// deprecated applies the configured deprecated policy to a deprecated person, as there are no redirects or query
+// parameters in gRPC. Hidden and gone people are not found, and superseded people resolve to their successor.
+func (s *GRPCServer) deprecated(ctx context.Context, concept Concept, chain []string, tid string) (Concept, []string, bool, error) {
+	policy := s.handler.configuredDeprecatedPolicy()
+	s.handler.metrics.observeDeprecatedPerson(string(policy))
+	switch policy {
+	case DeprecatedHide, DeprecatedGone:
+		return Concept{}, chain, false, nil
+	case DeprecatedSuccessor:
+		if successorId, ok := successor(concept); ok {
+			logger.WithTransactionID(tid).WithField("UUID", chain[0]).Infof(servingSuccessorPerson, chain[len(chain)-1], successorId)
+			return s.handler.resolvePersonConcept(ctx, successorId, tid)
+		}
+	}
+	return concept, chain, true, nil
+}
+
+func transactionIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return transactionidutils.NewTransactionID()
+}
+
+func toProtoPerson(p Person) *peoplepb.Person {
+	pb := &peoplepb.Person{
+		Id:              p.ID,
+		ApiUrl:          p.APIURL,
+		PrefLabel:       p.PrefLabel,
+		Types:           p.Types,
+		DirectType:      p.DirectType,
+		Labels:          p.Labels,
+		Salutation:      p.Salutation,
+		BirthYear:       int32(p.BirthYear),
+		EmailAddress:    p.EmailAddress,
+		TwitterHandle:   p.TwitterHandle,
+		FacebookProfile: p.FacebookProfile,
+		Description:     p.Description,
+		DescriptionXml:  p.DescriptionXML,
+		DescriptionHtml: p.DescriptionHTML,
+		DescriptionText: p.DescriptionText,
+		ImageUrl:        p.ImageURL,
+		IsDeprecated:    p.IsDeprecated,
+		ImageSet:        toProtoImageSet(p.ImageSet),
+	}
+	for _, m := range p.Memberships {
+		pb.Memberships = append(pb.Memberships, toProtoMembership(m))
+	}
+	return pb
+}
+
+func toProtoImageSet(set *ImageSet) *peoplepb.ImageSet {
+	if set == nil {
+		return nil
+	}
+	pb := &peoplepb.ImageSet{AltText: set.AltText}
+	if set.Preferred != nil {
+		pb.Preferred = toProtoImageRendition(*set.Preferred)
+	}
+	for _, r := range set.Renditions {
+		pb.Renditions = append(pb.Renditions, toProtoImageRendition(r))
+	}
+	return pb
+}
+
+func toProtoImageRendition(r ImageRendition) *peoplepb.ImageRendition {
+	return &peoplepb.ImageRendition{
+		Name:        r.Name,
+		Url:         r.URL,
+		Width:       int32(r.Width),
+		Height:      int32(r.Height),
+		AspectRatio: r.AspectRatio,
+		Format:      r.Format,
+	}
+}
+
+func toProtoMembership(m Membership) *peoplepb.Membership {
+	pb := &peoplepb.Membership{
+		Title:      m.Title,
+		Types:      m.Types,
+		DirectType: m.DirectType,
+		Organisation: &peoplepb.Organisation{
+			Id:         m.Organisation.ID,
+			ApiUrl:     m.Organisation.APIURL,
+			PrefLabel:  m.Organisation.PrefLabel,
+			Types:      m.Organisation.Types,
+			DirectType: m.Organisation.DirectType,
+			Labels:     m.Organisation.Labels,
+		},
+		ChangeEvents: toProtoChangeEvents(m.ChangeEvents),
+	}
+	for _, r := range m.Roles {
+		pb.Roles = append(pb.Roles, &peoplepb.Role{
+			Id:           r.ID,
+			ApiUrl:       r.APIURL,
+			PrefLabel:    r.PrefLabel,
+			Types:        r.Types,
+			DirectType:   r.DirectType,
+			ChangeEvents: toProtoChangeEvents(r.ChangeEvents),
+		})
+	}
+	return pb
+}
+
+func toProtoChangeEvents(events []ChangeEvent) []*peoplepb.ChangeEvent {
+	var pb []*peoplepb.ChangeEvent
+	for _, e := range events {
+		pb = append(pb, &peoplepb.ChangeEvent{StartedAt: e.StartedAt, EndedAt: e.EndedAt})
+	}
+	return pb
+}
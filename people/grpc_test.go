@@ -0,0 +1,223 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/public-people-api/v3/people/peoplepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+type GRPCServerTestSuite struct {
+	suite.Suite
+	listener *bufconn.Listener
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+	client   peoplepb.PeopleServiceClient
+	health   healthpb.HealthClient
+}
+
+func (suite *GRPCServerTestSuite) SetupTest() {
+	logger.InitDefaultLogger("grpc-test")
+	suite.listener = bufconn.Listen(1024 * 1024)
+	suite.server = grpc.NewServer()
+
+	handler := NewHandler(0, "http://localhost:8080", http.DefaultClient)
+	NewGRPCServer(handler).Register(suite.server)
+	checks := []fthealth.Check{
+		{
+			Name:    "Failing check",
+			Checker: func() (string, error) { return "", errors.New("generic.error") },
+		},
+	}
+	NewHealthCheckService(checks, HealthConfig{}).RegisterGRPCHealthServer(suite.server)
+	go suite.server.Serve(suite.listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return suite.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+	suite.client = peoplepb.NewPeopleServiceClient(conn)
+	suite.health = healthpb.NewHealthClient(conn)
+
+	httpmock.Activate()
+}
+
+func (suite *GRPCServerTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.conn.Close()
+	suite.server.Stop()
+}
+
+func (suite *GRPCServerTestSuite) TestGetPerson_Success() {
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDMetadataKey, "tid_grpc")
+	var header metadata.MD
+	person, err := suite.client.GetPerson(ctx, &peoplepb.GetPersonRequest{Uuid: uuid}, grpc.Header(&header))
+
+	suite.Require().NoError(err)
+	expected := getExpectedPerson(uuid, false)
+	suite.Equal(expected.ID, person.GetId())
+	suite.Equal(expected.PrefLabel, person.GetPrefLabel())
+	suite.Equal(expected.Types, person.GetTypes())
+	suite.Equal(expected.EmailAddress, person.GetEmailAddress())
+	suite.Equal(int32(expected.BirthYear), person.GetBirthYear())
+	suite.Require().Len(person.GetMemberships(), 1)
+	suite.Equal(expected.Memberships[0].Organisation.APIURL, person.GetMemberships()[0].GetOrganisation().GetApiUrl())
+	suite.Equal(expected.Memberships[0].Roles[0].ChangeEvents[0].StartedAt, person.GetMemberships()[0].GetRoles()[0].GetChangeEvents()[0].GetStartedAt())
+	suite.Equal([]string{"tid_grpc"}, header.Get(requestIDMetadataKey))
+	suite.Equal([]string{"max-age=0, public"}, header.Get("cache-control"))
+}
+
+func (suite *GRPCServerTestSuite) TestGetPerson_Errors() {
+	notFound := "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+	failing := "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+notFound, httpmock.NewStringResponder(404, "Not found"))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+failing, httpmock.NewErrorResponder(errors.New("boom")))
+
+	tests := map[string]codes.Code{
+		"BOO": codes.InvalidArgument,
+		"../../x-60e54253-1e94-38df-83b1-a39804d1ac18": codes.InvalidArgument,
+		notFound: codes.NotFound,
+		failing:  codes.Internal,
+	}
+	for uuid, code := range tests {
+		_, err := suite.client.GetPerson(context.Background(), &peoplepb.GetPersonRequest{Uuid: uuid})
+		suite.Equal(code, status.Code(err), uuid)
+	}
+}
+
+func (suite *GRPCServerTestSuite) TestBatchGetPeople() {
+	uuid, canonicalUUID := registerConcordedPerson()
+	missing := "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+missing, httpmock.NewStringResponder(404, "Not found"))
+
+	resp, err := suite.client.BatchGetPeople(context.Background(), &peoplepb.BatchGetPeopleRequest{Uuids: []string{uuid, missing, "BOO"}})
+
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.GetResults(), 3)
+	suite.Equal(peoplepb.PersonResult_STATUS_OK, resp.GetResults()[0].GetStatus())
+	suite.Equal(canonicalUUID, resp.GetResults()[0].GetCanonicalUuid())
+	suite.Equal("http://api.ft.com/things/"+canonicalUUID, resp.GetResults()[0].GetPerson().GetId())
+	suite.Equal(peoplepb.PersonResult_STATUS_NOT_FOUND, resp.GetResults()[1].GetStatus())
+	suite.Equal(peoplepb.PersonResult_STATUS_INVALID_UUID, resp.GetResults()[2].GetStatus())
+}
+
+func (suite *GRPCServerTestSuite) TestStreamPeople() {
+	uuids := []string{"60e54253-1e94-38df-83b1-a39804d1ac18", "8ec028a9-a5e7-49ae-8bd5-7cd0a57df1d6"}
+	for _, uuid := range uuids {
+		httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+			httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+	}
+
+	stream, err := suite.client.StreamPeople(context.Background(), &peoplepb.StreamPeopleRequest{Uuids: uuids})
+	suite.Require().NoError(err)
+
+	var received []string
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		suite.Equal(peoplepb.PersonResult_STATUS_OK, result.GetStatus())
+		received = append(received, result.GetUuid())
+	}
+	suite.Equal(uuids, received)
+}
+
+func (suite *GRPCServerTestSuite) TestStreamPeople_Gzip() {
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+
+	stream, err := suite.client.StreamPeople(context.Background(), &peoplepb.StreamPeopleRequest{Uuids: []string{uuid}}, grpc.UseCompressor(gzip.Name))
+	suite.Require().NoError(err)
+	result, err := stream.Recv()
+	suite.Require().NoError(err)
+	suite.Equal(peoplepb.PersonResult_STATUS_OK, result.GetStatus())
+	_, err = stream.Recv()
+	suite.Equal(io.EOF, err)
+}
+
+func (suite *GRPCServerTestSuite) TestHealthCheck_NotServing() {
+	resp, err := suite.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	suite.Require().NoError(err)
+	suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+// newGRPCTestClient serves the handler over gRPC on an in-memory listener, closed when the test ends
+func newGRPCTestClient(t *testing.T, handler *Handler) peoplepb.PeopleServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	NewGRPCServer(handler).Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return peoplepb.NewPeopleServiceClient(conn)
+}
+
+func TestGRPCServerTestSuite(t *testing.T) {
+	suite.Run(t, new(GRPCServerTestSuite))
+}
+
+func TestGetPerson_ImageSetAndDescriptions_GRPC(t *testing.T) {
+	logger.InitDefaultLogger("grpc-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, `"descriptionXML": "<p>Neil <ft-concept url=\"http://api.ft.com/things/1d448227-8b1b-3490-aeb8-18aa699d75f8\">Cole</ft-concept></p>",`)))
+
+	client := newGRPCTestClient(t, NewHandler(0, "http://localhost:8080", http.DefaultClient, WithImageService(ImageServiceConfig{
+		URLTemplate: "https://images.example.com/{url}?width={width}",
+		Renditions:  []RenditionSpec{{Name: "small", Width: 100, Height: 100, Format: "jpg"}},
+	})))
+	person, err := client.GetPerson(context.Background(), &peoplepb.GetPersonRequest{Uuid: uuid})
+	require.NoError(t, err)
+
+	assert.Equal(t, `<p>Neil <ft-concept url="http://api.ft.com/things/1d448227-8b1b-3490-aeb8-18aa699d75f8">Cole</ft-concept></p>`, person.GetDescriptionXml())
+	assert.Equal(t, `<p>Neil <a href="https://www.ft.com/stream/1d448227-8b1b-3490-aeb8-18aa699d75f8">Cole</a></p>`, person.GetDescriptionHtml())
+	assert.Equal(t, "Neil Cole", person.GetDescriptionText())
+
+	assert.Equal(t, "Neil Cole", person.GetImageSet().GetAltText())
+	require.Len(t, person.GetImageSet().GetRenditions(), 1)
+	rendition := person.GetImageSet().GetRenditions()[0]
+	assert.Equal(t, "small", rendition.GetName())
+	assert.Equal(t, int32(100), rendition.GetWidth())
+	assert.Equal(t, "jpg", rendition.GetFormat())
+	assert.Contains(t, rendition.GetUrl(), "https://images.example.com/")
+}
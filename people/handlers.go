@@ -1,10 +1,13 @@
 package people
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"io/ioutil"
+	"errors"
 	"net/http"
-	"net/url"
 
 	"fmt"
 	"html"
@@ -18,6 +21,12 @@ import (
 	"github.com/Financial-Times/transactionid-utils-go"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -29,19 +38,78 @@ const (
 	personUnableToBeRetrieved = "Person could not be retrieved"
 	badRequestMsg             = "Invalid UUID"
 	redirectedPerson          = "Person %s is concorded to %s; serving redirect"
+	servingCanonicalPerson    = "Person %s is concorded to %s; serving canonical person"
+	unsupportedVersionMsg     = "Version %s of the Person representation is not supported"
+
+	// personMethods are the methods the person routes allow
+	personMethods = "GET, HEAD, OPTIONS"
 )
 
+// validUUIDRegexp matches a whole UUID, as UUIDs from gRPC requests are not limited to a path segment
+var validUUIDRegexp = regexp.MustCompile("^" + validUUID)
+
 type Handler struct {
-	cacheDuration        time.Duration
-	publicConceptsApiURL string
-	client               *http.Client
+	cacheDuration    time.Duration
+	source           ConceptSource
+	images           ImageServiceConfig
+	metrics          *Metrics
+	debugToken       string
+	redactionPolicy  *RedactionPolicy
+	redirects        RedirectPolicy
+	concordanceDepth int
+	deprecated       DeprecatedPolicy
+	sunset           time.Time
+}
+
+// HandlerOption configures optional behaviour of a Handler
+type HandlerOption func(*Handler)
+
+// WithImageService adds an image set, derived from the concept's image URL, to every person
+func WithImageService(config ImageServiceConfig) HandlerOption {
+	return func(h *Handler) {
+		h.images = config
+	}
+}
+
+// WithMetrics records person lookup and public-concepts-api metrics
+func WithMetrics(m *Metrics) HandlerOption {
+	return func(h *Handler) {
+		h.metrics = m
+	}
+}
+
+// WithDebugToken enables /__debug/people/{uuid} for callers presenting the token as a bearer token
+func WithDebugToken(token string) HandlerOption {
+	return func(h *Handler) {
+		h.debugToken = token
+	}
 }
 
-func NewHandler(cacheDuration time.Duration, publicConceptsApiURL string, c *http.Client) *Handler {
+// WithRedactionPolicy hides or masks the person fields selected by the policy
+func WithRedactionPolicy(policy *RedactionPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.redactionPolicy = policy
+	}
+}
+
+// WithRedirectPolicy sets how requests for concorded UUIDs are answered, unless a request asks otherwise.
+// The default is a 301 redirect.
+func WithRedirectPolicy(policy RedirectPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.redirects = policy
+	}
+}
+
+func NewHandler(cacheDuration time.Duration, publicConceptsApiURL string, c *http.Client, opts ...HandlerOption) *Handler {
 	h := &Handler{
-		cacheDuration:        cacheDuration,
-		publicConceptsApiURL: publicConceptsApiURL,
-		client:               c,
+		cacheDuration:    cacheDuration,
+		concordanceDepth: defaultConcordanceDepth,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.source == nil {
+		h.source = NewHTTPConceptSource(publicConceptsApiURL, c, h.metrics)
 	}
 	return h
 }
@@ -49,125 +117,275 @@ func NewHandler(cacheDuration time.Duration, publicConceptsApiURL string, c *htt
 func (h *Handler) RegisterHandlers(router *mux.Router) {
 	logger.Info("Registering handlers")
 	handler := handlers.MethodHandler{
-		"GET": http.HandlerFunc(h.GetPerson),
+		"GET":     http.HandlerFunc(h.GetPerson),
+		"HEAD":    http.HandlerFunc(h.GetPerson),
+		"OPTIONS": http.HandlerFunc(personOptions),
 	}
 	router.Handle("/people/{uuid}", handler)
+	router.Handle("/v{version:[0-9]+}/people/{uuid}", handler)
 }
 
-// GetPerson is the public API
+// GetPerson is the public API. HEAD requests get the same status and headers as GET requests, without the body.
 func (h *Handler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodHead {
+		w = headResponseWriter{w}
+	}
 	vars := mux.Vars(r)
 	uuid := vars["uuid"]
 	transId := transactionidutils.GetTransactionIDFromRequest(r)
 	w.Header().Set("X-Request-Id", transId)
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Content-Type", contentTypeJson)
+	w.Header().Add("Vary", "Accept")
+
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := tracer().Start(ctx, "GetPerson", trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+		attribute.String("person.uuid", uuid),
+		attribute.String("transaction_id", transId),
+		semconv.HTTPRequestMethodKey.String(r.Method),
+	))
+
+	start := time.Now()
+	outcome, cacheable, canonicalId := outcomeBadRequest, false, ""
+	defer func() {
+		h.metrics.observePerson(outcome, cacheable, time.Since(start))
+		accessRecordFromContext(r.Context()).setPerson(uuid, canonicalId, outcome)
+		span.SetAttributes(attribute.String("person.outcome", outcome))
+		if outcome == outcomeError {
+			span.SetStatus(codes.Error, personUnableToBeRetrieved)
+		}
+		span.End()
+	}()
 
-	validRegexp := regexp.MustCompile(validUUID)
+	version, err := negotiateVersion(r)
+	if err != nil {
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(err.Error())
+		writeJSONStatus(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
 
-	if uuid == "" || !validRegexp.MatchString(uuid) {
+	if !IsValidUUID(uuid) {
 		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(badRequestMsg)
 		writeJSONStatus(w, badRequestMsg, http.StatusBadRequest)
 		return
 	}
 
-	person, found, err := h.getPersonViaConceptsAPI(uuid, transId)
+	rendition := r.URL.Query().Get(imageRenditionParam)
+	if rendition != "" && !h.images.hasRendition(rendition) {
+		msg := fmt.Sprintf(unknownRenditionMsg, rendition)
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(msg)
+		writeJSONStatus(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	descriptionFormat := r.URL.Query().Get(descriptionFormatParam)
+	if !validDescriptionFormat(descriptionFormat) {
+		msg := fmt.Sprintf(unknownDescriptionFormatMsg, descriptionFormat)
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(msg)
+		writeJSONStatus(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := h.redirectPolicy(r)
+	if err != nil {
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(err.Error())
+		writeJSONStatus(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deprecated, err := h.deprecatedPolicy(r)
 	if err != nil {
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Error(err.Error())
+		writeJSONStatus(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	concept, chain, found, err := h.resolvePersonConcept(ctx, uuid, transId)
+	if errors.Is(err, errConcordanceLoop) {
+		outcome = outcomeError
+		logger.WithError(err).WithTransactionID(transId).WithUUID(uuid).Error("Concordance chain could not be resolved")
+		writeJSONStatus(w, fmt.Sprintf(concordanceLoopMsg, uuid), http.StatusLoopDetected)
+		return
+	}
+	if errors.Is(err, errConcordanceTooLong) {
+		outcome = outcomeError
+		logger.WithError(err).WithTransactionID(transId).WithUUID(uuid).Error("Concordance chain could not be resolved")
+		writeJSONStatus(w, fmt.Sprintf(concordanceTooLongMsg, uuid, h.concordanceDepth), http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		outcome = outcomeError
 		writeJSONStatus(w, personUnableToBeRetrieved, http.StatusInternalServerError)
 		return
 	}
 	if !found {
+		outcome = outcomeNotFound
 		writeJSONStatus(w, personNotFoundMsg, http.StatusNotFound)
 		return
 	}
 
-	canonicalId := strings.TrimPrefix(person.ID, urlPrefix)
+	canonicalId = chain[len(chain)-1]
+	if concept.IsDeprecated {
+		h.metrics.observeDeprecatedPerson(string(deprecated))
+		switch deprecated {
+		case DeprecatedHide:
+			outcome = outcomeNotFound
+			writeJSONStatus(w, personNotFoundMsg, http.StatusNotFound)
+			return
+		case DeprecatedGone:
+			outcome = outcomeNotFound
+			writeJSONStatus(w, fmt.Sprintf(goneMsg, canonicalId), http.StatusGone)
+			return
+		case DeprecatedSuccessor:
+			if successorId, ok := successor(concept); ok {
+				outcome = outcomeRedirect
+				logger.WithTransactionID(transId).WithField("UUID", uuid).Infof(supersededPerson, canonicalId, successorId)
+				h.setDeprecationHeaders(w)
+				w.Header().Set("Location", canonicalURL(r.URL, uuid, successorId))
+				writeJSONStatus(w, fmt.Sprintf(supersededPerson, canonicalId, successorId), redirect.status())
+				return
+			}
+		}
+		h.setDeprecationHeaders(w)
+	}
+
 	if canonicalId != uuid {
-		logger.WithTransactionID(transId).WithField("UUID", uuid).Infof(redirectedPerson, uuid, canonicalId)
-		redirectURL := strings.Replace(r.URL.String(), uuid, canonicalId, 1)
-		w.Header().Set("Location", redirectURL)
-		writeJSONStatus(w, fmt.Sprintf(redirectedPerson, uuid, canonicalId), http.StatusMovedPermanently)
+		location := canonicalURL(r.URL, uuid, canonicalId)
+		if redirect != RedirectTransparent {
+			outcome = outcomeRedirect
+			logger.WithTransactionID(transId).WithField("UUID", uuid).Infof(redirectedPerson, uuid, canonicalId)
+			w.Header().Set("Location", location)
+			writeJSONStatus(w, fmt.Sprintf(redirectedPerson, uuid, canonicalId), redirect.status())
+			return
+		}
+		logger.WithTransactionID(transId).WithField("UUID", uuid).Infof(servingCanonicalPerson, uuid, canonicalId)
+		w.Header().Set("Content-Location", location)
+		w.Header().Set(canonicalIDHeader, canonicalId)
+	}
+
+	extras := personExtras{
+		ImageSet:          h.images.imageSet(concept.ImageURL, concept.PrefLabel, rendition),
+		DescriptionFormat: descriptionFormat,
+	}
+	if err := extras.renderDescription(concept.DescriptionXML); err != nil {
+		logger.WithError(err).WithTransactionID(transId).WithUUID(uuid).Warn("Description could not be fully rendered")
+	}
+	_, convertSpan := tracer().Start(ctx, "convertToPerson", trace.WithAttributes(attribute.String("person.version", version.name)))
+	person := filterPerson(ctx, version.convert(concept, extras))
+	convertSpan.End()
+
+	var body bytes.Buffer
+	if err = json.NewEncoder(&body).Encode(person); err != nil {
+		outcome = outcomeError
+		logger.WithError(err).WithTransactionID(transId).WithUUID(uuid).Error("Person could not be encoded")
+		writeJSONStatus(w, personUnableToBeRetrieved, http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%s, public", strconv.FormatFloat(h.cacheDuration.Seconds(), 'f', 0, 64)))
+	outcome, cacheable = outcomeOK, h.cacheDuration > 0
+	w.Header().Set("Content-Type", version.contentType)
+	w.Header().Set("Cache-Control", h.responseCacheControl(ctx))
+	w.Header().Set("ETag", etag(body.Bytes()))
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
 	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
 
-	if err = json.NewEncoder(w).Encode(person); err != nil {
-		writeJSONStatus(w, "Person could not be retrieved", http.StatusInternalServerError)
-	}
+// personOptions lists the methods of the person routes
+func personOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Allow", personMethods)
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *Handler) getPersonViaConceptsAPI(uuid, tid string) (person Person, found bool, err error) {
-	var p Person
+// etag is a strong validator of a response body
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
 
-	concept, err := h.getConcept(uuid, tid)
-	if err != nil {
-		if err.Error() == "Not found" {
-			return p, false, nil
-		}
-		return p, false, err
-	}
+// headResponseWriter discards the body of responses to HEAD requests, keeping their headers
+type headResponseWriter struct {
+	http.ResponseWriter
+}
 
-	if strings.Contains(concept.Type, "Person") == false {
-		logger.WithTransactionID(tid).Infof("Concept Type is not person. type %s, uuid: %s", concept.Type, uuid)
-		return p, false, nil
-	}
+func (w headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
 
-	convertToPerson(concept, &p)
+// IsValidUUID reports whether uuid is a well formed UUID
+func IsValidUUID(uuid string) bool {
+	return uuid != "" && validUUIDRegexp.MatchString(uuid)
+}
 
-	return p, true, nil
+func (h *Handler) cacheControl() string {
+	return fmt.Sprintf("max-age=%s, public", strconv.FormatFloat(h.cacheDuration.Seconds(), 'f', 0, 64))
 }
 
-func (h *Handler) getConcept(uuid, tid string) (concept Concept, err error) {
-	var c Concept
+// privateCacheControl is used for responses with fields that only some clients are allowed to see
+func (h *Handler) privateCacheControl() string {
+	return fmt.Sprintf("max-age=%s, private", strconv.FormatFloat(h.cacheDuration.Seconds(), 'f', 0, 64))
+}
 
-	u, err := url.Parse(h.publicConceptsApiURL)
-	if err != nil {
-		msg := fmt.Sprintf("URL of Concepts API is invalid of %s", uuid)
-		logger.WithError(err).WithUUID(uuid).WithTransactionID(tid).Error(msg)
-		return c, err
+// responseCacheControl is the cache control of a person served to the principal of the context, which is private
+// when the principal may see scoped fields
+func (h *Handler) responseCacheControl(ctx context.Context) string {
+	if principal, ok := principalFromContext(ctx); ok && principal.seesScopedFields() {
+		return h.privateCacheControl()
 	}
+	return h.cacheControl()
+}
 
-	u.Path = "/concepts/" + uuid
-	q := u.Query()
-	for _, query := range []string{"related"} {
-		q.Add("showRelationship", query)
-	}
-	u.RawQuery = q.Encode()
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return c, err
-	}
-	req.Header.Set("X-Request-Id", tid)
+func (h *Handler) getPersonViaConceptsAPI(ctx context.Context, uuid, tid string) (person Person, found bool, err error) {
+	var p Person
 
-	resp, err := h.client.Do(req)
-	if err != nil {
-		logger.WithError(err).WithTransactionID(tid).Warnf("API request failed")
-		return c, err
+	concept, _, found, err := h.resolvePersonConcept(ctx, uuid, tid)
+	if err != nil || !found {
+		return p, found, err
 	}
-	defer resp.Body.Close()
+	return h.convertPerson(ctx, concept), true, nil
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return c, fmt.Errorf("Not found")
-	}
+// convertPerson converts a resolved person concept to the Person served outside the versioned HTTP representations
+func (h *Handler) convertPerson(ctx context.Context, concept Concept) Person {
+	var p Person
+	_, span := tracer().Start(ctx, "convertToPerson")
+	convertToPerson(concept, &p)
+	p.ImageSet = h.images.imageSet(concept.ImageURL, concept.PrefLabel, "")
+	p = filterPerson(ctx, p).(Person)
+	span.End()
+	return p
+}
 
-	bytes, err := ioutil.ReadAll(resp.Body)
+// getPersonConcept also returns the UUID the concept was read for, which differs from uuid when public-concepts-api
+// redirected it to its canonical UUID. Other sources always report uuid.
+func (h *Handler) getPersonConcept(ctx context.Context, uuid, tid string) (concept Concept, served string, found bool, err error) {
+	ctx = transactionidutils.TransactionAwareContext(ctx, tid)
+	var c Concept
+	served = uuid
+	if api, ok := h.source.(*HTTPConceptSource); ok {
+		c, served, err = api.getConcept(ctx, uuid)
+	} else {
+		c, err = h.source.GetConcept(ctx, uuid)
+	}
 	if err != nil {
-		logger.WithError(err).WithTransactionID(tid).Warnf("Error reading response body")
-		return c, err
+		if errors.Is(err, ErrConceptNotFound) {
+			return c, served, false, nil
+		}
+		return c, served, false, err
 	}
 
-	if err := json.Unmarshal(bytes, &c); err != nil {
-		logger.WithError(err).WithTransactionID(tid).Warnf("Error parsing json")
-		return c, err
+	if strings.Contains(c.Type, "Person") == false {
+		logger.WithTransactionID(tid).Infof("Concept Type is not person. type %s, uuid: %s", c.Type, uuid)
+		return c, served, false, nil
 	}
-	return c, nil
+
+	return c, served, true, nil
 }
 
 func writeJSONStatus(rw http.ResponseWriter, message string, statusCode int) {
+	logMsg := fmt.Sprintf(`{"message":"%s"}`, html.EscapeString(message))
 	rw.Header().Set("Content-Type", contentTypeJson)
+	rw.Header().Set("Content-Length", strconv.Itoa(len(logMsg)))
 	rw.WriteHeader(statusCode)
-	logMsg := fmt.Sprintf(`{"message":"%s"}`, html.EscapeString(message))
 	if _, err := rw.Write([]byte(logMsg)); err != nil {
 		logger.WithError(err).Warnf("could not read json error")
 	}
@@ -185,22 +403,10 @@ func (h *Handler) Healthchecks() fthealth.Check {
 	}
 }
 
+// Checker checks the source of concepts, when it can be checked
 func (h *Handler) Checker() (string, error) {
-	req, err := http.NewRequest("GET", h.publicConceptsApiURL+"/__gtg", nil)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Add("User-Agent", "UPP public-people-api")
-	resp, err := h.client.Do(req)
-	if err != nil {
-		return "", err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("health check returned a non-200 HTTP status: %v", resp.StatusCode)
+	if checker, ok := h.source.(interface{ Checker() (string, error) }); ok {
+		return checker.Checker()
 	}
-	return "Public Concepts API is healthy", nil
+	return fmt.Sprintf("Concepts are read from %s", sourceName(h.source)), nil
 }
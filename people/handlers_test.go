@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/Financial-Times/go-logger"
 	"github.com/gorilla/mux"
@@ -190,6 +192,68 @@ func getExpectedPerson(uuid string, isDeprecated bool) Person {
 	}
 }
 
+func (suite *HandlerTestSuite) TestGetPeople_ImageSet() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+
+	router := mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient, WithImageService(ImageServiceConfig{
+		URLTemplate: "https://images.example.com/{url}?width={width}",
+		Renditions: []RenditionSpec{
+			{Name: "small", Width: 100, Height: 100, Format: "jpg"},
+			{Name: "large", Width: 480, Height: 480, Format: "jpg"},
+		},
+	})).RegisterHandlers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?imageRendition=large", ""))
+
+	retPerson := Person{}
+	json.NewDecoder(rec.Result().Body).Decode(&retPerson)
+	suite.Equal(http.StatusOK, rec.Result().StatusCode)
+	suite.Equal(getExpectedPerson(uuid, false).ImageURL, retPerson.ImageURL)
+	suite.Require().NotNil(retPerson.ImageSet)
+	suite.Equal("Neil Cole", retPerson.ImageSet.AltText)
+	suite.Len(retPerson.ImageSet.Renditions, 2)
+	suite.Equal("large", retPerson.ImageSet.Preferred.Name)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?imageRendition=huge", ""))
+	suite.Equal(http.StatusBadRequest, rec.Result().StatusCode)
+}
+
+func (suite *HandlerTestSuite) TestGetPeople_DescriptionFormat() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, `"descriptionXML": "<p>Neil <ft-concept url=\"http://api.ft.com/things/1d448227-8b1b-3490-aeb8-18aa699d75f8\">Cole</ft-concept></p>",`)))
+
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?descriptionFormat=html", ""))
+
+	retPerson := Person{}
+	json.NewDecoder(rec.Result().Body).Decode(&retPerson)
+	suite.Equal(http.StatusOK, rec.Result().StatusCode)
+	suite.Equal(`<p>Neil <a href="https://www.ft.com/stream/1d448227-8b1b-3490-aeb8-18aa699d75f8">Cole</a></p>`, retPerson.DescriptionHTML)
+	suite.Empty(retPerson.DescriptionXML)
+
+	rec = httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?descriptionFormat=text", ""))
+
+	retPerson = Person{}
+	json.NewDecoder(rec.Result().Body).Decode(&retPerson)
+	suite.Equal("Neil Cole", retPerson.DescriptionText)
+	suite.Empty(retPerson.DescriptionXML)
+
+	rec = httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?descriptionFormat=pdf", ""))
+	suite.Equal(http.StatusBadRequest, rec.Result().StatusCode)
+}
+
 func (suite *HandlerTestSuite) TestGetPeople_NotFound() {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -271,6 +335,7 @@ func (suite *HandlerTestSuite) TestGetPeople_Redirect() {
 	}`
 
 	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, fakeResponse))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+canonicalUUID, httpmock.NewStringResponder(200, fakeResponse))
 
 	req := newRequest("GET", "/people/"+uuid, "")
 	rec := httptest.NewRecorder()
@@ -286,6 +351,94 @@ func (suite *HandlerTestSuite) TestGetPeople_Redirect() {
 	suite.Equal(http.StatusMovedPermanently, rec.Result().StatusCode)
 }
 
+// registerConcordedPerson registers a person concorded to a canonical person with the concepts API
+func registerConcordedPerson() (string, string) {
+	uuid := "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	canonicalUUID := "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, canonicalUUID, canonicalUUID, "")))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+canonicalUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, canonicalUUID, canonicalUUID, "")))
+	return uuid, canonicalUUID
+}
+
+func (suite *HandlerTestSuite) TestGetPeople_RedirectKeepsQuery() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	uuid, canonicalUUID := registerConcordedPerson()
+
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("GET", "/v2/people/"+uuid+"?descriptionFormat=html&imageRendition=", ""))
+	suite.Equal(http.StatusMovedPermanently, rec.Code)
+	suite.Equal("/v2/people/"+canonicalUUID+"?descriptionFormat=html&imageRendition=", rec.Header().Get("Location"))
+}
+
+func (suite *HandlerTestSuite) TestGetPeople_RedirectPolicies() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	uuid, canonicalUUID := registerConcordedPerson()
+
+	router := newTestRouter(0, WithRedirectPolicy(RedirectPermanent))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid, ""))
+	suite.Equal(http.StatusPermanentRedirect, rec.Code)
+	suite.Equal("/people/"+canonicalUUID, rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?redirect=301", ""))
+	suite.Equal(http.StatusMovedPermanently, rec.Code)
+	suite.Equal("/people/"+canonicalUUID+"?redirect=301", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?redirect=transparent", ""))
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Empty(rec.Header().Get("Location"))
+	suite.Equal("/people/"+canonicalUUID+"?redirect=transparent", rec.Header().Get("Content-Location"))
+	suite.Equal(canonicalUUID, rec.Header().Get("X-Canonical-Id"))
+	person := Person{}
+	suite.Require().NoError(json.NewDecoder(rec.Body).Decode(&person))
+	suite.Equal("http://api.ft.com/things/"+canonicalUUID, person.ID)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid+"?redirect=302", ""))
+	suite.Equal(http.StatusBadRequest, rec.Code)
+	suite.JSONEq(`{"message":"Unknown redirect policy 302, expected 301, 308 or transparent"}`, rec.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestGetPeople_TransparentPolicyServesCanonicalPeopleDirectly() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+
+	router := newTestRouter(0, WithRedirectPolicy(RedirectTransparent))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/people/"+uuid, ""))
+	suite.Equal(http.StatusOK, rec.Code)
+	suite.Empty(rec.Header().Get("Content-Location"))
+	suite.Empty(rec.Header().Get("X-Canonical-Id"))
+}
+
+func (suite *HandlerTestSuite) TestIsValidUUID() {
+	suite.True(IsValidUUID("60e54253-1e94-38df-83b1-a39804d1ac18"))
+	for _, uuid := range []string{"", "BOO", "../../x-60e54253-1e94-38df-83b1-a39804d1ac18", "60e54253-1e94-38df-83b1-a39804d1ac18/.."} {
+		suite.False(IsValidUUID(uuid), uuid)
+	}
+}
+
+func (suite *HandlerTestSuite) TestParseRedirectPolicy() {
+	for _, policy := range []string{"301", "308", "transparent"} {
+		p, err := ParseRedirectPolicy(policy)
+		suite.NoError(err)
+		suite.Equal(RedirectPolicy(policy), p)
+	}
+	_, err := ParseRedirectPolicy("307")
+	suite.EqualError(err, "Unknown redirect policy 307, expected 301, 308 or transparent")
+}
+
 func (suite *HandlerTestSuite) TestGetPeople_InternalError() {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -315,10 +468,76 @@ func (suite *HandlerTestSuite) TestGetPeople_MethodNotAllowedOnPost() {
 	suite.Equal(http.StatusMethodNotAllowed, rec.Result().StatusCode)
 }
 
+func (suite *HandlerTestSuite) TestHeadPeople() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+
+	get := httptest.NewRecorder()
+	suite.router.ServeHTTP(get, newRequest("GET", "/people/"+uuid, ""))
+	head := httptest.NewRecorder()
+	suite.router.ServeHTTP(head, newRequest("HEAD", "/people/"+uuid, ""))
+
+	suite.Equal(http.StatusOK, head.Code)
+	suite.Empty(head.Body.Bytes())
+	suite.Equal(strconv.Itoa(get.Body.Len()), head.Header().Get("Content-Length"))
+	suite.NotEmpty(head.Header().Get("ETag"))
+	for _, header := range []string{"Content-Type", "Cache-Control", "ETag", "Content-Length"} {
+		suite.Equal(get.Header().Get(header), head.Header().Get(header), header)
+	}
+}
+
+func (suite *HandlerTestSuite) TestHeadPeople_Redirect() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid, _ := registerConcordedPerson()
+
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("HEAD", "/people/"+uuid, ""))
+	suite.Equal(http.StatusMovedPermanently, rec.Code)
+	suite.Equal("/people/2d3e16e0-61cb-4322-8aff-3b01c59f4daa", rec.Header().Get("Location"))
+	suite.NotEmpty(rec.Header().Get("Content-Length"))
+	suite.Empty(rec.Body.Bytes())
+}
+
+func (suite *HandlerTestSuite) TestHeadPeople_NotFound() {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(404, `{"message":"Concept not found"}`))
+
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("HEAD", "/people/"+uuid, ""))
+	suite.Equal(http.StatusNotFound, rec.Code)
+	suite.Empty(rec.Body.Bytes())
+}
+
+func (suite *HandlerTestSuite) TestOptionsPeople() {
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, newRequest("OPTIONS", "/v2/people/70f4732b-7f7d-30a1-9c29-0cceec23760e", ""))
+	suite.Equal(http.StatusNoContent, rec.Code)
+	suite.Equal("GET, HEAD, OPTIONS", rec.Header().Get("Allow"))
+}
+
 func TestHandlersTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
 
+// newTestRouter routes the person endpoints of a handler reading from the mocked public-concepts-api, with its
+// metrics at /metrics
+func newTestRouter(cacheDuration time.Duration, options ...HandlerOption) *mux.Router {
+	logger.InitDefaultLogger("handler-test")
+	metrics := NewMetrics()
+	router := mux.NewRouter()
+	NewHandler(cacheDuration, "http://localhost:8080", http.DefaultClient, append([]HandlerOption{WithMetrics(metrics)}, options...)...).RegisterHandlers(router)
+	metrics.RegisterHandlers(router)
+	return router
+}
+
 func newRequest(method, url string, body string) *http.Request {
 	var payload io.Reader
 	if body != "" {
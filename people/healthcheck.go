@@ -1,7 +1,11 @@
 package people
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -11,13 +15,21 @@ import (
 	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
 	"github.com/Financial-Times/go-logger"
 	"github.com/Financial-Times/http-handlers-go/httphandlers"
+	"github.com/Financial-Times/public-people-api/v3/people/peoplepb"
 	"github.com/Financial-Times/service-status-go/gtg"
 	st "github.com/Financial-Times/service-status-go/httphandlers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
+var errNotChecked = errors.New("check has not run yet")
+
 type HealthcheckService struct {
 	config HealthConfig
 	Checks []fthealth.Check
+	cached []*cachedCheck
 }
 
 type HealthConfig struct {
@@ -25,42 +37,103 @@ type HealthConfig struct {
 	AppName           string
 	Description       string
 	ReqLoggingEnabled bool
+	// CheckInterval runs the checks in the background at this interval, and /__health and /__gtg serve
+	// their latest results. Zero runs the checks on every request.
+	CheckInterval time.Duration
+	// CheckStaleAfter fails a background check whose latest result is older than this. Zero never fails stale results.
+	CheckStaleAfter time.Duration
+	// Lifecycle fails readiness and /__gtg once shutdown has started
+	Lifecycle *Lifecycle
+	// ReportOnlyChecks are reported by /__health only. They do not fail /__gtg, readiness or the gRPC health service,
+	// and are not run in the background, so they should be cheap or keep their own latest result.
+	ReportOnlyChecks []fthealth.Check
 }
 
 func NewHealthCheckService(checks []fthealth.Check, config HealthConfig) *HealthcheckService {
-	return &HealthcheckService{
+	s := &HealthcheckService{
 		config: config,
 		Checks: checks,
 	}
+	if config.CheckInterval > 0 {
+		s.Checks = make([]fthealth.Check, len(checks))
+		for i, check := range checks {
+			cached := &cachedCheck{checker: check.Checker, staleAfter: config.CheckStaleAfter, err: errNotChecked}
+			check.Checker = cached.Checker
+			s.Checks[i] = check
+			s.cached = append(s.cached, cached)
+		}
+	}
+	return s
+}
+
+// Start runs the checks straight away and then on every CheckInterval until ctx is done.
+// It does nothing unless CheckInterval is set.
+func (s *HealthcheckService) Start(ctx context.Context) {
+	if len(s.cached) == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			s.runChecks()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *HealthcheckService) runChecks() {
+	var wg sync.WaitGroup
+	for _, cached := range s.cached {
+		wg.Add(1)
+		go func(c *cachedCheck) {
+			defer wg.Done()
+			c.run()
+		}(cached)
+	}
+	wg.Wait()
 }
 
 func (s HealthcheckService) RegisterAdminHandlers(router *mux.Router) http.Handler {
 	logger.Info("Registering admin handlers")
+	s.RegisterHealthHandlers(router)
 
+	var monitoringRouter http.Handler = router
+	if s.config.ReqLoggingEnabled {
+		monitoringRouter = httphandlers.TransactionAwareRequestLoggingHandler(log.StandardLogger(), monitoringRouter)
+		monitoringRouter = httphandlers.HTTPMetricsHandler(metrics.DefaultRegistry, monitoringRouter)
+	}
+
+	return monitoringRouter
+}
+
+// RegisterHealthHandlers registers /__health, /__gtg, /__build-info and the liveness and readiness endpoints
+func (s HealthcheckService) RegisterHealthHandlers(router *mux.Router) {
 	timedHC := fthealth.TimedHealthCheck{
 		HealthCheck: fthealth.HealthCheck{
 			SystemCode:  s.config.AppSystemCode,
 			Name:        s.config.AppName,
 			Description: s.config.Description,
-			Checks:      s.Checks,
+			Checks:      append(append([]fthealth.Check{}, s.Checks...), s.config.ReportOnlyChecks...),
 		},
 		Timeout: 8 * time.Second,
 	}
 
 	router.HandleFunc("/__health", fthealth.Handler(&timedHC))
 	router.HandleFunc("/__gtg", st.NewGoodToGoHandler(s.gtg))
+	router.HandleFunc(readinessPath, st.NewGoodToGoHandler(s.gtg))
+	router.HandleFunc(livenessPath, st.NewGoodToGoHandler(live))
 	router.HandleFunc(st.BuildInfoPath, st.BuildInfoHandler)
-
-	var monitoringRouter http.Handler = router
-	if s.config.ReqLoggingEnabled {
-		monitoringRouter = httphandlers.TransactionAwareRequestLoggingHandler(log.StandardLogger(), monitoringRouter)
-		monitoringRouter = httphandlers.HTTPMetricsHandler(metrics.DefaultRegistry, monitoringRouter)
-	}
-
-	return monitoringRouter
 }
 
 func (s HealthcheckService) gtg() gtg.Status {
+	if s.config.Lifecycle.Draining() {
+		return gtg.Status{GoodToGo: false, Message: "Service is shutting down"}
+	}
 	var sc []gtg.StatusChecker
 	for _, check := range s.Checks {
 		statusCheck := func() gtg.Status {
@@ -71,6 +144,11 @@ func (s HealthcheckService) gtg() gtg.Status {
 	return gtg.FailFastParallelCheck(sc)()
 }
 
+// live reports that the process is up and serving, regardless of upstream and shutdown state
+func live() gtg.Status {
+	return gtg.Status{GoodToGo: true}
+}
+
 func gtgCheck(handler func() (string, error)) gtg.Status {
 	if _, err := handler(); err != nil {
 		return gtg.Status{
@@ -82,3 +160,61 @@ func gtgCheck(handler func() (string, error)) gtg.Status {
 		GoodToGo: true,
 	}
 }
+
+// RegisterGRPCHealthServer exposes the same checks as /__gtg through the standard gRPC health protocol
+func (s HealthcheckService) RegisterGRPCHealthServer(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, &grpcHealthServer{gtg: s.gtg})
+}
+
+type grpcHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	gtg func() gtg.Status
+}
+
+func (g *grpcHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" && req.GetService() != peoplepb.PeopleService_ServiceDesc.ServiceName {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.GetService())
+	}
+	if !g.gtg().GoodToGo {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// cachedCheck keeps the latest result of a check run in the background
+type cachedCheck struct {
+	checker    func() (string, error)
+	staleAfter time.Duration
+
+	mu      sync.RWMutex
+	output  string
+	err     error
+	updated time.Time
+}
+
+func (c *cachedCheck) run() {
+	output, err := c.checker()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.output, c.err, c.updated = output, err, time.Now()
+}
+
+// Checker returns the latest result together with when it was last updated
+func (c *cachedCheck) Checker() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.updated.IsZero() {
+		return "", c.err
+	}
+	updated := c.updated.Format(time.RFC3339)
+	if c.staleAfter > 0 && time.Since(c.updated) > c.staleAfter {
+		return c.output, fmt.Errorf("check result is stale, last updated at %s", updated)
+	}
+	if c.err != nil {
+		return c.output, fmt.Errorf("%w (last updated at %s)", c.err, updated)
+	}
+	if c.output == "" {
+		return "Last updated at " + updated, nil
+	}
+	return fmt.Sprintf("%s (last updated at %s)", c.output, updated), nil
+}
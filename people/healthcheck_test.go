@@ -1,8 +1,11 @@
 package people
 
 import (
+	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 
 	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -65,3 +69,101 @@ func (suite *HealthCheckTestTestSuite) TestGtg_Success() {
 func TestHealthCheckTestSuite(t *testing.T) {
 	suite.Run(t, new(HealthCheckTestTestSuite))
 }
+
+func TestHealthCheckService_BackgroundChecks(t *testing.T) {
+	var calls int32
+	var failing atomic.Value
+	failing.Store(false)
+	checks := []fthealth.Check{
+		{
+			Name: "Test healthcheck",
+			Checker: func() (string, error) {
+				atomic.AddInt32(&calls, 1)
+				if failing.Load().(bool) {
+					return "", errors.New("upstream down")
+				}
+				return "OK", nil
+			},
+		},
+	}
+	service := NewHealthCheckService(checks, HealthConfig{CheckInterval: time.Hour})
+	router := mux.NewRouter()
+	service.RegisterAdminHandlers(router)
+
+	gtg := func() int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newRequest("GET", "/__gtg", ""))
+		return rec.Code
+	}
+
+	_, err := service.Checks[0].Checker()
+	assert.Equal(t, errNotChecked, err)
+	assert.Equal(t, http.StatusServiceUnavailable, gtg())
+
+	service.runChecks()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, gtg())
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "gtg should serve the cached result")
+
+	output, err := service.Checks[0].Checker()
+	assert.NoError(t, err)
+	assert.Contains(t, output, "OK (last updated at ")
+
+	failing.Store(true)
+	service.runChecks()
+	_, err = service.Checks[0].Checker()
+	assert.EqualError(t, err, "upstream down (last updated at "+service.cached[0].updated.Format(time.RFC3339)+")")
+	assert.Equal(t, http.StatusServiceUnavailable, gtg())
+}
+
+func TestHealthCheckService_StaleResultFailsGtg(t *testing.T) {
+	checks := []fthealth.Check{{Name: "Test healthcheck", Checker: func() (string, error) { return "OK", nil }}}
+	service := NewHealthCheckService(checks, HealthConfig{CheckInterval: time.Hour, CheckStaleAfter: time.Minute})
+
+	service.runChecks()
+	assert.True(t, service.gtg().GoodToGo)
+
+	service.cached[0].updated = time.Now().Add(-2 * time.Minute)
+	status := service.gtg()
+	assert.False(t, status.GoodToGo)
+	assert.Contains(t, status.Message, "stale")
+}
+
+func TestHealthCheckService_Start(t *testing.T) {
+	var calls int32
+	checks := []fthealth.Check{{Name: "Test healthcheck", Checker: func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "OK", nil
+	}}}
+	service := NewHealthCheckService(checks, HealthConfig{CheckInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service.Start(ctx)
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) >= 2 }, time.Second, 5*time.Millisecond)
+	assert.True(t, service.gtg().GoodToGo)
+}
+
+func TestHealthCheckService_ReportOnlyChecks(t *testing.T) {
+	checks := []fthealth.Check{{Name: "Test healthcheck", Checker: func() (string, error) { return "OK", nil }}}
+	service := NewHealthCheckService(checks, HealthConfig{ReportOnlyChecks: []fthealth.Check{{
+		Name:    "Canary healthcheck",
+		Checker: func() (string, error) { return "", errors.New("canary not found") },
+	}}})
+	router := mux.NewRouter()
+	service.RegisterAdminHandlers(router)
+
+	for _, path := range []string{"/__gtg", readinessPath} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newRequest("GET", path, ""))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/__health", ""))
+	assert.Contains(t, rec.Body.String(), `"name":"Canary healthcheck"`)
+	assert.Contains(t, rec.Body.String(), "canary not found")
+	assert.Contains(t, rec.Body.String(), `"ok":false`)
+}
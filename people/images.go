@@ -0,0 +1,133 @@
+package people
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	imageRenditionParam = "imageRendition"
+
+	unknownRenditionMsg = "Unknown image rendition %s"
+)
+
+// ImageSet is the set of renditions of a person's image, generated by the image service
+type ImageSet struct {
+	AltText    string           `json:"altText,omitempty"`
+	Preferred  *ImageRendition  `json:"preferred,omitempty"`
+	Renditions []ImageRendition `json:"renditions"`
+}
+
+// ImageRendition is one size, aspect ratio and format of an image
+type ImageRendition struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	AspectRatio string `json:"aspectRatio"`
+	Format      string `json:"format"`
+}
+
+// RenditionSpec describes a rendition the image service should generate
+type RenditionSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Format string
+}
+
+// ImageServiceConfig configures how image sets are derived from a concept's image URL.
+// URLTemplate may contain the placeholders {url}, {width}, {height} and {format};
+// {url} is replaced by the query-escaped source image URL.
+type ImageServiceConfig struct {
+	URLTemplate string
+	Renditions  []RenditionSpec
+}
+
+// ParseRenditionSpecs parses a comma separated list of renditions in the form name:WIDTHxHEIGHT:format,
+// e.g. "square-small:100x100:jpg,landscape:640x360:webp"
+func ParseRenditionSpecs(specs string) ([]RenditionSpec, error) {
+	var renditions []RenditionSpec
+	for _, spec := range strings.Split(specs, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		parts := strings.Split(spec, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid image rendition %q, expected name:WIDTHxHEIGHT:format", spec)
+		}
+		size := strings.Split(parts[1], "x")
+		if len(size) != 2 {
+			return nil, fmt.Errorf("invalid image rendition size %q in %q", parts[1], spec)
+		}
+		width, err := strconv.Atoi(size[0])
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("invalid image rendition width %q in %q", size[0], spec)
+		}
+		height, err := strconv.Atoi(size[1])
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("invalid image rendition height %q in %q", size[1], spec)
+		}
+		renditions = append(renditions, RenditionSpec{
+			Name:   parts[0],
+			Width:  width,
+			Height: height,
+			Format: parts[2],
+		})
+	}
+	return renditions, nil
+}
+
+// imageSet builds the image set for an image URL. It returns nil when there is no image
+// or no image service is configured.
+func (c ImageServiceConfig) imageSet(imageURL, altText, preferred string) *ImageSet {
+	if imageURL == "" || c.URLTemplate == "" || len(c.Renditions) == 0 {
+		return nil
+	}
+
+	set := &ImageSet{AltText: altText}
+	for _, spec := range c.Renditions {
+		rendition := ImageRendition{
+			Name:        spec.Name,
+			URL:         c.renditionURL(imageURL, spec),
+			Width:       spec.Width,
+			Height:      spec.Height,
+			AspectRatio: aspectRatio(spec.Width, spec.Height),
+			Format:      spec.Format,
+		}
+		set.Renditions = append(set.Renditions, rendition)
+		if spec.Name == preferred {
+			set.Preferred = &rendition
+		}
+	}
+	return set
+}
+
+func (c ImageServiceConfig) hasRendition(name string) bool {
+	for _, spec := range c.Renditions {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ImageServiceConfig) renditionURL(imageURL string, spec RenditionSpec) string {
+	return strings.NewReplacer(
+		"{url}", url.QueryEscape(imageURL),
+		"{width}", strconv.Itoa(spec.Width),
+		"{height}", strconv.Itoa(spec.Height),
+		"{format}", spec.Format,
+	).Replace(c.URLTemplate)
+}
+
+func aspectRatio(width, height int) string {
+	a, b := width, height
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return fmt.Sprintf("%d:%d", width/a, height/a)
+}
@@ -0,0 +1,46 @@
+package people
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRenditionSpecs(t *testing.T) {
+	specs, err := ParseRenditionSpecs("square:100x100:jpg, landscape:640x360:webp")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []RenditionSpec{
+		{Name: "square", Width: 100, Height: 100, Format: "jpg"},
+		{Name: "landscape", Width: 640, Height: 360, Format: "webp"},
+	}, specs)
+}
+
+func TestParseRenditionSpecs_Invalid(t *testing.T) {
+	for _, spec := range []string{"square", "square:100:jpg", "square:0x100:jpg", "square:100xabc:jpg", ":100x100:jpg"} {
+		_, err := ParseRenditionSpecs(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestImageSet(t *testing.T) {
+	config := ImageServiceConfig{
+		URLTemplate: "https://images.example.com/{url}?width={width}&height={height}&format={format}",
+		Renditions: []RenditionSpec{
+			{Name: "square", Width: 100, Height: 100, Format: "jpg"},
+			{Name: "landscape", Width: 640, Height: 360, Format: "webp"},
+		},
+	}
+
+	set := config.imageSet("https://example.com/a.jpg?source=next", "Neil Cole", "landscape")
+
+	assert.Equal(t, "Neil Cole", set.AltText)
+	assert.Len(t, set.Renditions, 2)
+	assert.Equal(t, "https://images.example.com/https%3A%2F%2Fexample.com%2Fa.jpg%3Fsource%3Dnext?width=100&height=100&format=jpg", set.Renditions[0].URL)
+	assert.Equal(t, "1:1", set.Renditions[0].AspectRatio)
+	assert.Equal(t, "16:9", set.Renditions[1].AspectRatio)
+	assert.Equal(t, "landscape", set.Preferred.Name)
+
+	assert.Nil(t, config.imageSet("", "Neil Cole", ""))
+	assert.Nil(t, ImageServiceConfig{}.imageSet("https://example.com/a.jpg", "Neil Cole", ""))
+}
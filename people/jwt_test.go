@@ -0,0 +1,159 @@
+package people
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct {
+	kid string
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newRSASigner(t *testing.T, kid string) testSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testSigner{kid: kid, rsa: key}
+}
+
+func newECSigner(t *testing.T, kid string) testSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testSigner{kid: kid, ec: key}
+}
+
+func (s testSigner) jwk() map[string]string {
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	if s.rsa != nil {
+		return map[string]string{"kty": "RSA", "kid": s.kid, "use": "sig", "n": b64(s.rsa.N.Bytes()), "e": b64(big.NewInt(int64(s.rsa.E)).Bytes())}
+	}
+	return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "x": b64(s.ec.X.FillBytes(make([]byte, 32))), "y": b64(s.ec.Y.FillBytes(make([]byte, 32)))}
+}
+
+func (s testSigner) sign(t *testing.T, claims map[string]interface{}) string {
+	alg := "RS256"
+	if s.ec != nil {
+		alg = "ES256"
+	}
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": s.kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	if s.rsa != nil {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsa, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	} else {
+		r, sig, err := ecdsa.Sign(rand.Reader, s.ec, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func testJWKS(t *testing.T, signers ...testSigner) *JWKS {
+	var keys []map[string]string
+	for _, s := range signers {
+		keys = append(keys, s.jwk())
+	}
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	jwks, err := ParseJWKS(data)
+	require.NoError(t, err)
+	return jwks
+}
+
+func TestJWKS_Verify(t *testing.T) {
+	rsaSigner, ecSigner := newRSASigner(t, "rsa-1"), newECSigner(t, "ec-1")
+	jwks := testJWKS(t, rsaSigner, ecSigner)
+	now := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":   "next-article",
+			"iss":   "https://auth.ft.com",
+			"aud":   []string{"public-people-api", "public-things-api"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"nbf":   now.Add(-time.Hour).Unix(),
+			"scope": "people:pii people:social",
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	for _, signer := range []testSigner{rsaSigner, ecSigner} {
+		parsed, err := jwks.verify(signer.sign(t, claims(nil)), "https://auth.ft.com", "public-people-api", now)
+		require.NoError(t, err, signer.kid)
+		assert.Equal(t, "next-article", parsed.name())
+		assert.Equal(t, []string{ScopePII, ScopeSocial}, parsed.scopes())
+	}
+
+	parsed, err := jwks.verify(rsaSigner.sign(t, claims(map[string]interface{}{"scope": nil, "scp": []string{ScopePII}, "client_id": "spark", "aud": "public-people-api"})), "", "public-people-api", now)
+	require.NoError(t, err)
+	assert.Equal(t, "spark", parsed.name())
+	assert.Equal(t, []string{ScopePII}, parsed.scopes())
+
+	other := newRSASigner(t, "rsa-1")
+	tests := []struct {
+		name  string
+		token string
+		err   string
+	}{
+		{"expired", rsaSigner.sign(t, claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})), "token has expired"},
+		{"no expiry", rsaSigner.sign(t, claims(map[string]interface{}{"exp": nil})), "token has no expiry"},
+		{"not valid yet", rsaSigner.sign(t, claims(map[string]interface{}{"nbf": now.Add(time.Minute).Unix()})), "token is not valid yet"},
+		{"wrong issuer", rsaSigner.sign(t, claims(map[string]interface{}{"iss": "https://evil.example.com"})), "wrong issuer"},
+		{"wrong audience", rsaSigner.sign(t, claims(map[string]interface{}{"aud": "public-things-api"})), "wrong audience"},
+		{"unknown key", other.sign(t, claims(nil)), "invalid token signature"},
+		{"unknown kid", newECSigner(t, "ec-2").sign(t, claims(nil)), "invalid token signature"},
+		{"malformed", "not.a-token", "malformed token"},
+	}
+	for _, test := range tests {
+		_, err := jwks.verify(test.token, "https://auth.ft.com", "public-people-api", now)
+		assert.ErrorContains(t, err, test.err, test.name)
+	}
+
+	_, err = jwks.verify(rsaSigner.sign(t, claims(map[string]interface{}{"exp": now.Add(-10 * time.Second).Unix()})), "", "", now)
+	assert.NoError(t, err, "small clock skew should be tolerated")
+}
+
+func TestLoadJWKSFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	data, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{newECSigner(t, "ec-1").jwk(), {"kty": "RSA", "use": "enc", "n": "AQAB", "e": "AQAB"}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	jwks, err := LoadJWKSFile(path)
+	require.NoError(t, err)
+	assert.Len(t, jwks.keys, 1, "encryption keys should be ignored")
+
+	for _, invalid := range []string{`{`, `{"keys": []}`, `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`, `{"keys": [{"kty": "EC", "crv": "P-384", "x": "AQ", "y": "AQ"}]}`} {
+		_, err := ParseJWKS([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+	_, err = LoadJWKSFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "could not read JWKS file")
+}
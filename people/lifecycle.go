@@ -0,0 +1,77 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"google.golang.org/grpc"
+)
+
+const (
+	livenessPath  = "/__live"
+	readinessPath = "/__ready"
+)
+
+// Lifecycle tracks whether the service should receive traffic, and shuts it down by failing readiness,
+// waiting for load balancers to notice and then draining in-flight requests
+type Lifecycle struct {
+	grace    time.Duration
+	timeout  time.Duration
+	draining atomic.Bool
+	sleep    func(time.Duration)
+}
+
+// NewLifecycle keeps serving for grace after readiness starts failing, then allows timeout for in-flight requests to complete
+func NewLifecycle(grace, timeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		grace:   grace,
+		timeout: timeout,
+		sleep:   time.Sleep,
+	}
+}
+
+// Draining reports whether shutdown has started. A nil *Lifecycle never drains.
+func (l *Lifecycle) Draining() bool {
+	return l != nil && l.draining.Load()
+}
+
+// Shutdown fails readiness straight away, waits for the grace period and then calls each stop function in order,
+// all sharing the shutdown timeout. Stop functions are called even if an earlier one fails.
+func (l *Lifecycle) Shutdown(stops ...func(context.Context) error) error {
+	l.draining.Store(true)
+	logger.Infof("Readiness is failing, waiting %s before draining requests", l.grace)
+	l.sleep(l.grace)
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	var errs []error
+	for _, stop := range stops {
+		if err := stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GracefulStopGRPC returns a stop function that waits for in-flight RPCs to complete,
+// and closes the remaining connections when the context is done
+func GracefulStopGRPC(server *grpc.Server) func(context.Context) error {
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			<-stopped
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package people
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestLifecycle_ReadinessFailsBeforeDrain(t *testing.T) {
+	lifecycle := NewLifecycle(time.Second, time.Second)
+	checks := []fthealth.Check{{Name: "Test healthcheck", Checker: func() (string, error) { return "OK", nil }}}
+	router := mux.NewRouter()
+	NewHealthCheckService(checks, HealthConfig{Lifecycle: lifecycle}).RegisterAdminHandlers(router)
+
+	status := func(path string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newRequest("GET", path, ""))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusOK, status(readinessPath))
+	assert.Equal(t, http.StatusOK, status("/__gtg"))
+	assert.Equal(t, http.StatusOK, status(livenessPath))
+
+	var steps []string
+	lifecycle.sleep = func(d time.Duration) {
+		assert.Equal(t, time.Second, d)
+		assert.Equal(t, http.StatusServiceUnavailable, status(readinessPath))
+		assert.Equal(t, http.StatusServiceUnavailable, status("/__gtg"))
+		assert.Equal(t, http.StatusOK, status(livenessPath))
+		steps = append(steps, "grace")
+	}
+	err := lifecycle.Shutdown(
+		func(context.Context) error { steps = append(steps, "http"); return nil },
+		func(context.Context) error { steps = append(steps, "tracing"); return nil },
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"grace", "http", "tracing"}, steps)
+}
+
+func TestLifecycle_LivenessIgnoresFailingChecks(t *testing.T) {
+	checks := []fthealth.Check{{Name: "Test healthcheck", Checker: func() (string, error) { return "", errors.New("upstream down") }}}
+	router := mux.NewRouter()
+	NewHealthCheckService(checks, HealthConfig{}).RegisterAdminHandlers(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", livenessPath, ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", readinessPath, ""))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestLifecycle_DrainsInFlightRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+
+	result := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + lis.Addr().String())
+		if err != nil {
+			result <- 0
+			return
+		}
+		resp.Body.Close()
+		result <- resp.StatusCode
+	}()
+	<-started
+
+	lifecycle := NewLifecycle(0, 5*time.Second)
+	lifecycle.sleep = func(time.Duration) { close(release) }
+	assert.NoError(t, lifecycle.Shutdown(server.Shutdown))
+	assert.Equal(t, http.StatusOK, <-result)
+	assert.True(t, lifecycle.Draining())
+}
+
+func TestLifecycle_ShutdownTimeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	go http.Get("http://" + lis.Addr().String())
+	<-started
+
+	var stopped bool
+	lifecycle := NewLifecycle(0, 50*time.Millisecond)
+	err = lifecycle.Shutdown(server.Shutdown, func(context.Context) error { stopped = true; return nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, stopped, "later stop functions should still be called")
+}
+
+func TestGracefulStopGRPC(t *testing.T) {
+	server := grpc.NewServer()
+	lis := bufconn.Listen(1024 * 1024)
+	go server.Serve(lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, GracefulStopGRPC(server)(ctx))
+}
@@ -0,0 +1,237 @@
+package people
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsNamespace = "public_people_api"
+	metricsPath      = "/metrics"
+
+	outcomeOK         = "ok"
+	outcomeNotFound   = "notfound"
+	outcomeRedirect   = "redirect"
+	outcomeBadRequest = "badrequest"
+	outcomeError      = "error"
+
+	unmatchedRoute = "unmatched"
+)
+
+// Metrics holds the Prometheus collectors of the service. A nil *Metrics records nothing.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+
+	getPersonDuration *prometheus.HistogramVec
+	responses         *prometheus.CounterVec
+
+	upstreamDuration *prometheus.HistogramVec
+	upstreamInFlight prometheus.Gauge
+
+	rateLimitDecisions *prometheus.CounterVec
+
+	concordanceChainLength prometheus.Histogram
+	concordanceErrors      *prometheus.CounterVec
+
+	deprecatedPeople *prometheus.CounterVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		getPersonDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "get_person_duration_seconds",
+			Help:      "Latency of person lookups by outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		responses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "person_responses_total",
+			Help:      "Person lookups by outcome (ok, notfound, redirect, badrequest, error) and whether the response is cacheable.",
+		}, []string{"outcome", "cacheable"}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "concepts_api_request_duration_seconds",
+			Help:      "Latency of requests to public-concepts-api by status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"status"}),
+		upstreamInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "concepts_api_requests_in_flight",
+			Help:      "Requests to public-concepts-api currently in progress.",
+		}),
+		rateLimitDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rate_limit_requests_total",
+			Help:      "Rate limited requests by client and decision (allowed, limited). Clients without their own limit are anonymous.",
+		}, []string{"client", "decision"}),
+		concordanceChainLength: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "concordance_chain_length",
+			Help:      "Canonical ID hops followed to resolve a person, 0 for people requested by their canonical UUID.",
+			Buckets:   []float64{0, 1, 2, 3, 5, 10},
+		}),
+		concordanceErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "concordance_errors_total",
+			Help:      "Concordance chains that could not be resolved, by reason (loop, too_long).",
+		}, []string{"reason"}),
+		deprecatedPeople: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "deprecated_person_requests_total",
+			Help:      "Requests for deprecated people, by the deprecated policy applied (serve, hide, gone, successor).",
+		}, []string{"policy"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.requestsInFlight,
+		m.getPersonDuration,
+		m.responses,
+		m.upstreamDuration,
+		m.upstreamInFlight,
+		m.rateLimitDecisions,
+		m.concordanceChainLength,
+		m.concordanceErrors,
+		m.deprecatedPeople,
+	)
+	return m
+}
+
+// RegisterHandlers exposes the metrics in Prometheus exposition format on /metrics
+func (m *Metrics) RegisterHandlers(router *mux.Router) {
+	router.Handle(metricsPath, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// Instrument records request counts, latencies and in-flight requests for every request served by next,
+// labelled with the route template of router that matches the request
+func (m *Metrics) Instrument(router *mux.Router, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatchedRoute
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if tpl, err := match.Route.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		m.requestsInFlight.Inc()
+		defer m.requestsInFlight.Dec()
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		m.requests.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		m.requestDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+	})
+}
+
+func (m *Metrics) observePerson(outcome string, cacheable bool, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.getPersonDuration.WithLabelValues(outcome).Observe(d.Seconds())
+	m.responses.WithLabelValues(outcome, strconv.FormatBool(cacheable)).Inc()
+}
+
+// upstreamStarted records a request to public-concepts-api and returns the function that records its completion
+func (m *Metrics) upstreamStarted() func(status int) {
+	if m == nil {
+		return func(int) {}
+	}
+	start := time.Now()
+	m.upstreamInFlight.Inc()
+	return func(status int) {
+		m.upstreamInFlight.Dec()
+		label := "error"
+		if status != 0 {
+			label = strconv.Itoa(status)
+		}
+		m.upstreamDuration.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (m *Metrics) observeRateLimit(client string, allowed bool) {
+	if m == nil {
+		return
+	}
+	decision := "allowed"
+	if !allowed {
+		decision = "limited"
+	}
+	m.rateLimitDecisions.WithLabelValues(client, decision).Inc()
+}
+
+func (m *Metrics) observeConcordanceChain(hops int) {
+	if m == nil {
+		return
+	}
+	m.concordanceChainLength.Observe(float64(hops))
+}
+
+func (m *Metrics) observeConcordanceError(reason string) {
+	if m == nil {
+		return
+	}
+	m.concordanceErrors.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) observeDeprecatedPerson(policy string) {
+	if m == nil {
+		return
+	}
+	m.deprecatedPeople.WithLabelValues(policy).Inc()
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush sends any buffered response to the client, when the underlying writer can
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,52 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestMetrics(t *testing.T) {
+	logger.InitDefaultLogger("metrics-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	missing := "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+missing, httpmock.NewStringResponder(404, "Not found"))
+
+	metrics := NewMetrics()
+	router := mux.NewRouter()
+	NewHandler(time.Minute, "http://localhost:8080", http.DefaultClient, WithMetrics(metrics)).RegisterHandlers(router)
+	metrics.RegisterHandlers(router)
+	instrumented := metrics.Instrument(router, router)
+
+	for _, path := range []string{"/people/" + uuid, "/people/" + missing, "/people/BOO", "/nowhere"} {
+		instrumented.ServeHTTP(httptest.NewRecorder(), newRequest("GET", path, ""))
+	}
+
+	rec := httptest.NewRecorder()
+	instrumented.ServeHTTP(rec, newRequest("GET", "/metrics", ""))
+	body := rec.Body.String()
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, `public_people_api_http_requests_total{method="GET",route="/people/{uuid}",status="200"} 1`)
+	assert.Contains(t, body, `public_people_api_http_requests_total{method="GET",route="/people/{uuid}",status="404"} 1`)
+	assert.Contains(t, body, `public_people_api_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `public_people_api_person_responses_total{cacheable="true",outcome="ok"} 1`)
+	assert.Contains(t, body, `public_people_api_person_responses_total{cacheable="false",outcome="notfound"} 1`)
+	assert.Contains(t, body, `public_people_api_person_responses_total{cacheable="false",outcome="badrequest"} 1`)
+	assert.Contains(t, body, `public_people_api_get_person_duration_seconds_count{outcome="ok"} 1`)
+	assert.Contains(t, body, `public_people_api_concepts_api_request_duration_seconds_count{status="200"} 1`)
+	assert.Contains(t, body, `public_people_api_concepts_api_request_duration_seconds_count{status="404"} 1`)
+	assert.Contains(t, body, `public_people_api_http_requests_in_flight 1`)
+	assert.Contains(t, body, `public_people_api_concepts_api_requests_in_flight 0`)
+}
@@ -21,7 +21,10 @@ type Person struct {
 	FacebookProfile string       `json:"facebookProfile,omitempty"`
 	Description     string       `json:"description,omitempty"`
 	DescriptionXML  string       `json:"descriptionXML,omitempty"`
-	ImageURL        string       `json:"_imageUrl,omitempty"` // TODO we should implement this properly as an imageset
+	DescriptionHTML string       `json:"descriptionHTML,omitempty"`
+	DescriptionText string       `json:"descriptionText,omitempty"`
+	ImageURL        string       `json:"_imageUrl,omitempty"` // kept for backward compatibility, use ImageSet instead
+	ImageSet        *ImageSet    `json:"imageSet,omitempty"`
 	IsDeprecated    bool         `json:"isDeprecated,omitempty"`
 }
 
@@ -104,8 +107,9 @@ type Concept struct {
 	PostalCode             string `json:"postalCode,omitempty"`
 	YearFounded            int    `json:"yearFounded,omitempty"`
 	// Relations
-	BroaderConcepts  []PredicateConcept `json:"broaderConcepts,omitempty"`
-	NarrowerConcepts []PredicateConcept `json:"narrowerConcepts,omitempty"`
-	RelatedConcepts  []PredicateConcept `json:"relatedConcepts,omitempty"`
-	IsDeprecated     bool               `json:"isDeprecated,omitempty"`
+	BroaderConcepts   []PredicateConcept `json:"broaderConcepts,omitempty"`
+	NarrowerConcepts  []PredicateConcept `json:"narrowerConcepts,omitempty"`
+	RelatedConcepts   []PredicateConcept `json:"relatedConcepts,omitempty"`
+	IsDeprecated      bool               `json:"isDeprecated,omitempty"`
+	SupersededByUUIDs []string           `json:"supersededByUUIDs,omitempty"`
 }
@@ -0,0 +1,66 @@
+package people
+
+// PersonV2 is the version 2 representation of a person. Compared to Person it replaces the flat
+// labels list with typed alternative labels, groups the social and contact accounts, and exposes
+// the image under a properly named field.
+type PersonV2 struct {
+	ID                string               `json:"id"`
+	APIURL            string               `json:"apiUrl"`
+	PrefLabel         string               `json:"prefLabel,omitempty"`
+	Types             []string             `json:"types"`
+	DirectType        string               `json:"directType,omitempty"`
+	AlternativeLabels []AlternativeLabelV2 `json:"alternativeLabels,omitempty"`
+	Salutation        string               `json:"salutation,omitempty"`
+	BirthYear         int                  `json:"birthYear,omitempty"`
+	Accounts          *AccountsV2          `json:"accounts,omitempty"`
+	Description       string               `json:"description,omitempty"`
+	DescriptionXML    string               `json:"descriptionXML,omitempty"`
+	DescriptionHTML   string               `json:"descriptionHTML,omitempty"`
+	DescriptionText   string               `json:"descriptionText,omitempty"`
+	ImageURL          string               `json:"imageUrl,omitempty"`
+	ImageSet          *ImageSet            `json:"imageSet,omitempty"`
+	Memberships       []MembershipV2       `json:"memberships,omitempty"`
+	IsDeprecated      bool                 `json:"isDeprecated,omitempty"`
+}
+
+// AlternativeLabelV2 is a label together with the kind of label it is, e.g. an alias or a former name
+type AlternativeLabelV2 struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// AccountsV2 groups the contact and social media accounts of a person
+type AccountsV2 struct {
+	EmailAddress    string `json:"emailAddress,omitempty"`
+	TwitterHandle   string `json:"twitterHandle,omitempty"`
+	FacebookProfile string `json:"facebookProfile,omitempty"`
+}
+
+// MembershipV2 represents the relationship between a person and their roles associated with an organisation
+type MembershipV2 struct {
+	Title        string          `json:"title,omitempty"`
+	Types        []string        `json:"types"`
+	DirectType   string          `json:"directType,omitempty"`
+	Organisation *OrganisationV2 `json:"organisation,omitempty"`
+	ChangeEvents []ChangeEvent   `json:"changeEvents,omitempty"`
+	Roles        []RoleV2        `json:"roles,omitempty"`
+}
+
+// OrganisationV2 simplified representation used in version 2 of the Person API
+type OrganisationV2 struct {
+	ID         string   `json:"id"`
+	APIURL     string   `json:"apiUrl"`
+	PrefLabel  string   `json:"prefLabel,omitempty"`
+	Types      []string `json:"types"`
+	DirectType string   `json:"directType,omitempty"`
+}
+
+// RoleV2 represents the capacity or function that a person performs for an organisation
+type RoleV2 struct {
+	ID           string        `json:"id"`
+	APIURL       string        `json:"apiUrl"`
+	PrefLabel    string        `json:"prefLabel,omitempty"`
+	Types        []string      `json:"types"`
+	DirectType   string        `json:"directType,omitempty"`
+	ChangeEvents []ChangeEvent `json:"changeEvents,omitempty"`
+}
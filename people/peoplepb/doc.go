@@ -0,0 +1,4 @@
+// Package peoplepb contains the protobuf messages and gRPC service definition for the people API.
+package peoplepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative people.proto
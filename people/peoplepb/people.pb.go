@@ -0,0 +1,1155 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: people.proto
+
+package peoplepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PersonResult_Status int32
+
+const (
+	PersonResult_STATUS_UNSPECIFIED  PersonResult_Status = 0
+	PersonResult_STATUS_OK           PersonResult_Status = 1
+	PersonResult_STATUS_NOT_FOUND    PersonResult_Status = 2
+	PersonResult_STATUS_INVALID_UUID PersonResult_Status = 3
+	PersonResult_STATUS_ERROR        PersonResult_Status = 4
+)
+
+// Enum value maps for PersonResult_Status.
+var (
+	PersonResult_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_OK",
+		2: "STATUS_NOT_FOUND",
+		3: "STATUS_INVALID_UUID",
+		4: "STATUS_ERROR",
+	}
+	PersonResult_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED":  0,
+		"STATUS_OK":           1,
+		"STATUS_NOT_FOUND":    2,
+		"STATUS_INVALID_UUID": 3,
+		"STATUS_ERROR":        4,
+	}
+)
+
+func (x PersonResult_Status) Enum() *PersonResult_Status {
+	p := new(PersonResult_Status)
+	*p = x
+	return p
+}
+
+func (x PersonResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PersonResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_people_proto_enumTypes[0].Descriptor()
+}
+
+func (PersonResult_Status) Type() protoreflect.EnumType {
+	return &file_people_proto_enumTypes[0]
+}
+
+func (x PersonResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PersonResult_Status.Descriptor instead.
+func (PersonResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type GetPersonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPersonRequest) Reset() {
+	*x = GetPersonRequest{}
+	mi := &file_people_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPersonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPersonRequest) ProtoMessage() {}
+
+func (x *GetPersonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPersonRequest.ProtoReflect.Descriptor instead.
+func (*GetPersonRequest) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPersonRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type BatchGetPeopleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPeopleRequest) Reset() {
+	*x = BatchGetPeopleRequest{}
+	mi := &file_people_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPeopleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPeopleRequest) ProtoMessage() {}
+
+func (x *BatchGetPeopleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPeopleRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPeopleRequest) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchGetPeopleRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type BatchGetPeopleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*PersonResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPeopleResponse) Reset() {
+	*x = BatchGetPeopleResponse{}
+	mi := &file_people_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPeopleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPeopleResponse) ProtoMessage() {}
+
+func (x *BatchGetPeopleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPeopleResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPeopleResponse) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetPeopleResponse) GetResults() []*PersonResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type StreamPeopleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPeopleRequest) Reset() {
+	*x = StreamPeopleRequest{}
+	mi := &file_people_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPeopleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPeopleRequest) ProtoMessage() {}
+
+func (x *StreamPeopleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPeopleRequest.ProtoReflect.Descriptor instead.
+func (*StreamPeopleRequest) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamPeopleRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+// PersonResult carries the outcome of looking up a single UUID.
+type PersonResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Uuid   string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Status PersonResult_Status    `protobuf:"varint,2,opt,name=status,proto3,enum=ft.upp.people.v1.PersonResult_Status" json:"status,omitempty"`
+	// canonical_uuid is set when uuid is concorded to a different canonical person, or is a deprecated person served
+	// as its successor.
+	CanonicalUuid string  `protobuf:"bytes,3,opt,name=canonical_uuid,json=canonicalUuid,proto3" json:"canonical_uuid,omitempty"`
+	Person        *Person `protobuf:"bytes,4,opt,name=person,proto3" json:"person,omitempty"`
+	Message       string  `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonResult) Reset() {
+	*x = PersonResult{}
+	mi := &file_people_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonResult) ProtoMessage() {}
+
+func (x *PersonResult) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonResult.ProtoReflect.Descriptor instead.
+func (*PersonResult) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PersonResult) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *PersonResult) GetStatus() PersonResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return PersonResult_STATUS_UNSPECIFIED
+}
+
+func (x *PersonResult) GetCanonicalUuid() string {
+	if x != nil {
+		return x.CanonicalUuid
+	}
+	return ""
+}
+
+func (x *PersonResult) GetPerson() *Person {
+	if x != nil {
+		return x.Person
+	}
+	return nil
+}
+
+func (x *PersonResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Person mirrors people.Person.
+type Person struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApiUrl          string                 `protobuf:"bytes,2,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"`
+	PrefLabel       string                 `protobuf:"bytes,3,opt,name=pref_label,json=prefLabel,proto3" json:"pref_label,omitempty"`
+	Types           []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	DirectType      string                 `protobuf:"bytes,5,opt,name=direct_type,json=directType,proto3" json:"direct_type,omitempty"`
+	Labels          []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	Memberships     []*Membership          `protobuf:"bytes,7,rep,name=memberships,proto3" json:"memberships,omitempty"`
+	Salutation      string                 `protobuf:"bytes,8,opt,name=salutation,proto3" json:"salutation,omitempty"`
+	BirthYear       int32                  `protobuf:"varint,9,opt,name=birth_year,json=birthYear,proto3" json:"birth_year,omitempty"`
+	EmailAddress    string                 `protobuf:"bytes,10,opt,name=email_address,json=emailAddress,proto3" json:"email_address,omitempty"`
+	TwitterHandle   string                 `protobuf:"bytes,11,opt,name=twitter_handle,json=twitterHandle,proto3" json:"twitter_handle,omitempty"`
+	FacebookProfile string                 `protobuf:"bytes,12,opt,name=facebook_profile,json=facebookProfile,proto3" json:"facebook_profile,omitempty"`
+	Description     string                 `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	DescriptionXml  string                 `protobuf:"bytes,14,opt,name=description_xml,json=descriptionXml,proto3" json:"description_xml,omitempty"`
+	ImageUrl        string                 `protobuf:"bytes,15,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	IsDeprecated    bool                   `protobuf:"varint,16,opt,name=is_deprecated,json=isDeprecated,proto3" json:"is_deprecated,omitempty"`
+	// description_html and description_text are description_xml rendered as sanitized HTML and as plain text.
+	DescriptionHtml string    `protobuf:"bytes,17,opt,name=description_html,json=descriptionHtml,proto3" json:"description_html,omitempty"`
+	DescriptionText string    `protobuf:"bytes,18,opt,name=description_text,json=descriptionText,proto3" json:"description_text,omitempty"`
+	ImageSet        *ImageSet `protobuf:"bytes,19,opt,name=image_set,json=imageSet,proto3" json:"image_set,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	mi := &file_people_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Person) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Person) ProtoMessage() {}
+
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Person) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Person) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+func (x *Person) GetPrefLabel() string {
+	if x != nil {
+		return x.PrefLabel
+	}
+	return ""
+}
+
+func (x *Person) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Person) GetDirectType() string {
+	if x != nil {
+		return x.DirectType
+	}
+	return ""
+}
+
+func (x *Person) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Person) GetMemberships() []*Membership {
+	if x != nil {
+		return x.Memberships
+	}
+	return nil
+}
+
+func (x *Person) GetSalutation() string {
+	if x != nil {
+		return x.Salutation
+	}
+	return ""
+}
+
+func (x *Person) GetBirthYear() int32 {
+	if x != nil {
+		return x.BirthYear
+	}
+	return 0
+}
+
+func (x *Person) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *Person) GetTwitterHandle() string {
+	if x != nil {
+		return x.TwitterHandle
+	}
+	return ""
+}
+
+func (x *Person) GetFacebookProfile() string {
+	if x != nil {
+		return x.FacebookProfile
+	}
+	return ""
+}
+
+func (x *Person) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Person) GetDescriptionXml() string {
+	if x != nil {
+		return x.DescriptionXml
+	}
+	return ""
+}
+
+func (x *Person) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Person) GetIsDeprecated() bool {
+	if x != nil {
+		return x.IsDeprecated
+	}
+	return false
+}
+
+func (x *Person) GetDescriptionHtml() string {
+	if x != nil {
+		return x.DescriptionHtml
+	}
+	return ""
+}
+
+func (x *Person) GetDescriptionText() string {
+	if x != nil {
+		return x.DescriptionText
+	}
+	return ""
+}
+
+func (x *Person) GetImageSet() *ImageSet {
+	if x != nil {
+		return x.ImageSet
+	}
+	return nil
+}
+
+// ImageSet mirrors people.ImageSet.
+type ImageSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AltText       string                 `protobuf:"bytes,1,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Preferred     *ImageRendition        `protobuf:"bytes,2,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	Renditions    []*ImageRendition      `protobuf:"bytes,3,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageSet) Reset() {
+	*x = ImageSet{}
+	mi := &file_people_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageSet) ProtoMessage() {}
+
+func (x *ImageSet) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageSet.ProtoReflect.Descriptor instead.
+func (*ImageSet) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImageSet) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ImageSet) GetPreferred() *ImageRendition {
+	if x != nil {
+		return x.Preferred
+	}
+	return nil
+}
+
+func (x *ImageSet) GetRenditions() []*ImageRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+// ImageRendition mirrors people.ImageRendition.
+type ImageRendition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	AspectRatio   string                 `protobuf:"bytes,5,opt,name=aspect_ratio,json=aspectRatio,proto3" json:"aspect_ratio,omitempty"`
+	Format        string                 `protobuf:"bytes,6,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageRendition) Reset() {
+	*x = ImageRendition{}
+	mi := &file_people_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageRendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageRendition) ProtoMessage() {}
+
+func (x *ImageRendition) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageRendition.ProtoReflect.Descriptor instead.
+func (*ImageRendition) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImageRendition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageRendition) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageRendition) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageRendition) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageRendition) GetAspectRatio() string {
+	if x != nil {
+		return x.AspectRatio
+	}
+	return ""
+}
+
+func (x *ImageRendition) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// Membership mirrors people.Membership.
+type Membership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	DirectType    string                 `protobuf:"bytes,3,opt,name=direct_type,json=directType,proto3" json:"direct_type,omitempty"`
+	Organisation  *Organisation          `protobuf:"bytes,4,opt,name=organisation,proto3" json:"organisation,omitempty"`
+	ChangeEvents  []*ChangeEvent         `protobuf:"bytes,5,rep,name=change_events,json=changeEvents,proto3" json:"change_events,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,6,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_people_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Membership) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Membership) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Membership) GetDirectType() string {
+	if x != nil {
+		return x.DirectType
+	}
+	return ""
+}
+
+func (x *Membership) GetOrganisation() *Organisation {
+	if x != nil {
+		return x.Organisation
+	}
+	return nil
+}
+
+func (x *Membership) GetChangeEvents() []*ChangeEvent {
+	if x != nil {
+		return x.ChangeEvents
+	}
+	return nil
+}
+
+func (x *Membership) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// Organisation mirrors people.Organisation.
+type Organisation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApiUrl        string                 `protobuf:"bytes,2,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"`
+	PrefLabel     string                 `protobuf:"bytes,3,opt,name=pref_label,json=prefLabel,proto3" json:"pref_label,omitempty"`
+	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	DirectType    string                 `protobuf:"bytes,5,opt,name=direct_type,json=directType,proto3" json:"direct_type,omitempty"`
+	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organisation) Reset() {
+	*x = Organisation{}
+	mi := &file_people_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organisation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organisation) ProtoMessage() {}
+
+func (x *Organisation) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organisation.ProtoReflect.Descriptor instead.
+func (*Organisation) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Organisation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organisation) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+func (x *Organisation) GetPrefLabel() string {
+	if x != nil {
+		return x.PrefLabel
+	}
+	return ""
+}
+
+func (x *Organisation) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Organisation) GetDirectType() string {
+	if x != nil {
+		return x.DirectType
+	}
+	return ""
+}
+
+func (x *Organisation) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// Role mirrors people.Role.
+type Role struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApiUrl        string                 `protobuf:"bytes,2,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"`
+	PrefLabel     string                 `protobuf:"bytes,3,opt,name=pref_label,json=prefLabel,proto3" json:"pref_label,omitempty"`
+	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	DirectType    string                 `protobuf:"bytes,5,opt,name=direct_type,json=directType,proto3" json:"direct_type,omitempty"`
+	ChangeEvents  []*ChangeEvent         `protobuf:"bytes,6,rep,name=change_events,json=changeEvents,proto3" json:"change_events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_people_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Role) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Role) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+func (x *Role) GetPrefLabel() string {
+	if x != nil {
+		return x.PrefLabel
+	}
+	return ""
+}
+
+func (x *Role) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Role) GetDirectType() string {
+	if x != nil {
+		return x.DirectType
+	}
+	return ""
+}
+
+func (x *Role) GetChangeEvents() []*ChangeEvent {
+	if x != nil {
+		return x.ChangeEvents
+	}
+	return nil
+}
+
+// ChangeEvent mirrors people.ChangeEvent.
+type ChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt     string                 `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       string                 `protobuf:"bytes,2,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_people_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_people_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_people_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChangeEvent) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetEndedAt() string {
+	if x != nil {
+		return x.EndedAt
+	}
+	return ""
+}
+
+var File_people_proto protoreflect.FileDescriptor
+
+const file_people_proto_rawDesc = "" +
+	"\n" +
+	"\fpeople.proto\x12\x10ft.upp.people.v1\"&\n" +
+	"\x10GetPersonRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"-\n" +
+	"\x15BatchGetPeopleRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"R\n" +
+	"\x16BatchGetPeopleResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.ft.upp.people.v1.PersonResultR\aresults\"+\n" +
+	"\x13StreamPeopleRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"\xc6\x02\n" +
+	"\fPersonResult\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12=\n" +
+	"\x06status\x18\x02 \x01(\x0e2%.ft.upp.people.v1.PersonResult.StatusR\x06status\x12%\n" +
+	"\x0ecanonical_uuid\x18\x03 \x01(\tR\rcanonicalUuid\x120\n" +
+	"\x06person\x18\x04 \x01(\v2\x18.ft.upp.people.v1.PersonR\x06person\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"p\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tSTATUS_OK\x10\x01\x12\x14\n" +
+	"\x10STATUS_NOT_FOUND\x10\x02\x12\x17\n" +
+	"\x13STATUS_INVALID_UUID\x10\x03\x12\x10\n" +
+	"\fSTATUS_ERROR\x10\x04\"\xb1\x05\n" +
+	"\x06Person\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aapi_url\x18\x02 \x01(\tR\x06apiUrl\x12\x1d\n" +
+	"\n" +
+	"pref_label\x18\x03 \x01(\tR\tprefLabel\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x1f\n" +
+	"\vdirect_type\x18\x05 \x01(\tR\n" +
+	"directType\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\x12>\n" +
+	"\vmemberships\x18\a \x03(\v2\x1c.ft.upp.people.v1.MembershipR\vmemberships\x12\x1e\n" +
+	"\n" +
+	"salutation\x18\b \x01(\tR\n" +
+	"salutation\x12\x1d\n" +
+	"\n" +
+	"birth_year\x18\t \x01(\x05R\tbirthYear\x12#\n" +
+	"\remail_address\x18\n" +
+	" \x01(\tR\femailAddress\x12%\n" +
+	"\x0etwitter_handle\x18\v \x01(\tR\rtwitterHandle\x12)\n" +
+	"\x10facebook_profile\x18\f \x01(\tR\x0ffacebookProfile\x12 \n" +
+	"\vdescription\x18\r \x01(\tR\vdescription\x12'\n" +
+	"\x0fdescription_xml\x18\x0e \x01(\tR\x0edescriptionXml\x12\x1b\n" +
+	"\timage_url\x18\x0f \x01(\tR\bimageUrl\x12#\n" +
+	"\ris_deprecated\x18\x10 \x01(\bR\fisDeprecated\x12)\n" +
+	"\x10description_html\x18\x11 \x01(\tR\x0fdescriptionHtml\x12)\n" +
+	"\x10description_text\x18\x12 \x01(\tR\x0fdescriptionText\x127\n" +
+	"\timage_set\x18\x13 \x01(\v2\x1a.ft.upp.people.v1.ImageSetR\bimageSet\"\xa7\x01\n" +
+	"\bImageSet\x12\x19\n" +
+	"\balt_text\x18\x01 \x01(\tR\aaltText\x12>\n" +
+	"\tpreferred\x18\x02 \x01(\v2 .ft.upp.people.v1.ImageRenditionR\tpreferred\x12@\n" +
+	"\n" +
+	"renditions\x18\x03 \x03(\v2 .ft.upp.people.v1.ImageRenditionR\n" +
+	"renditions\"\x9f\x01\n" +
+	"\x0eImageRendition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12!\n" +
+	"\faspect_ratio\x18\x05 \x01(\tR\vaspectRatio\x12\x16\n" +
+	"\x06format\x18\x06 \x01(\tR\x06format\"\x8f\x02\n" +
+	"\n" +
+	"Membership\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x1f\n" +
+	"\vdirect_type\x18\x03 \x01(\tR\n" +
+	"directType\x12B\n" +
+	"\forganisation\x18\x04 \x01(\v2\x1e.ft.upp.people.v1.OrganisationR\forganisation\x12B\n" +
+	"\rchange_events\x18\x05 \x03(\v2\x1d.ft.upp.people.v1.ChangeEventR\fchangeEvents\x12,\n" +
+	"\x05roles\x18\x06 \x03(\v2\x16.ft.upp.people.v1.RoleR\x05roles\"\xa5\x01\n" +
+	"\fOrganisation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aapi_url\x18\x02 \x01(\tR\x06apiUrl\x12\x1d\n" +
+	"\n" +
+	"pref_label\x18\x03 \x01(\tR\tprefLabel\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x1f\n" +
+	"\vdirect_type\x18\x05 \x01(\tR\n" +
+	"directType\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\"\xc9\x01\n" +
+	"\x04Role\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aapi_url\x18\x02 \x01(\tR\x06apiUrl\x12\x1d\n" +
+	"\n" +
+	"pref_label\x18\x03 \x01(\tR\tprefLabel\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x1f\n" +
+	"\vdirect_type\x18\x05 \x01(\tR\n" +
+	"directType\x12B\n" +
+	"\rchange_events\x18\x06 \x03(\v2\x1d.ft.upp.people.v1.ChangeEventR\fchangeEvents\"G\n" +
+	"\vChangeEvent\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\tR\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\x02 \x01(\tR\aendedAt2\x98\x02\n" +
+	"\rPeopleService\x12I\n" +
+	"\tGetPerson\x12\".ft.upp.people.v1.GetPersonRequest\x1a\x18.ft.upp.people.v1.Person\x12c\n" +
+	"\x0eBatchGetPeople\x12'.ft.upp.people.v1.BatchGetPeopleRequest\x1a(.ft.upp.people.v1.BatchGetPeopleResponse\x12W\n" +
+	"\fStreamPeople\x12%.ft.upp.people.v1.StreamPeopleRequest\x1a\x1e.ft.upp.people.v1.PersonResult0\x01BAZ?github.com/Financial-Times/public-people-api/v3/people/peoplepbb\x06proto3"
+
+var (
+	file_people_proto_rawDescOnce sync.Once
+	file_people_proto_rawDescData []byte
+)
+
+func file_people_proto_rawDescGZIP() []byte {
+	file_people_proto_rawDescOnce.Do(func() {
+		file_people_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_people_proto_rawDesc), len(file_people_proto_rawDesc)))
+	})
+	return file_people_proto_rawDescData
+}
+
+var file_people_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_people_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_people_proto_goTypes = []any{
+	(PersonResult_Status)(0),       // 0: ft.upp.people.v1.PersonResult.Status
+	(*GetPersonRequest)(nil),       // 1: ft.upp.people.v1.GetPersonRequest
+	(*BatchGetPeopleRequest)(nil),  // 2: ft.upp.people.v1.BatchGetPeopleRequest
+	(*BatchGetPeopleResponse)(nil), // 3: ft.upp.people.v1.BatchGetPeopleResponse
+	(*StreamPeopleRequest)(nil),    // 4: ft.upp.people.v1.StreamPeopleRequest
+	(*PersonResult)(nil),           // 5: ft.upp.people.v1.PersonResult
+	(*Person)(nil),                 // 6: ft.upp.people.v1.Person
+	(*ImageSet)(nil),               // 7: ft.upp.people.v1.ImageSet
+	(*ImageRendition)(nil),         // 8: ft.upp.people.v1.ImageRendition
+	(*Membership)(nil),             // 9: ft.upp.people.v1.Membership
+	(*Organisation)(nil),           // 10: ft.upp.people.v1.Organisation
+	(*Role)(nil),                   // 11: ft.upp.people.v1.Role
+	(*ChangeEvent)(nil),            // 12: ft.upp.people.v1.ChangeEvent
+}
+var file_people_proto_depIdxs = []int32{
+	5,  // 0: ft.upp.people.v1.BatchGetPeopleResponse.results:type_name -> ft.upp.people.v1.PersonResult
+	0,  // 1: ft.upp.people.v1.PersonResult.status:type_name -> ft.upp.people.v1.PersonResult.Status
+	6,  // 2: ft.upp.people.v1.PersonResult.person:type_name -> ft.upp.people.v1.Person
+	9,  // 3: ft.upp.people.v1.Person.memberships:type_name -> ft.upp.people.v1.Membership
+	7,  // 4: ft.upp.people.v1.Person.image_set:type_name -> ft.upp.people.v1.ImageSet
+	8,  // 5: ft.upp.people.v1.ImageSet.preferred:type_name -> ft.upp.people.v1.ImageRendition
+	8,  // 6: ft.upp.people.v1.ImageSet.renditions:type_name -> ft.upp.people.v1.ImageRendition
+	10, // 7: ft.upp.people.v1.Membership.organisation:type_name -> ft.upp.people.v1.Organisation
+	12, // 8: ft.upp.people.v1.Membership.change_events:type_name -> ft.upp.people.v1.ChangeEvent
+	11, // 9: ft.upp.people.v1.Membership.roles:type_name -> ft.upp.people.v1.Role
+	12, // 10: ft.upp.people.v1.Role.change_events:type_name -> ft.upp.people.v1.ChangeEvent
+	1,  // 11: ft.upp.people.v1.PeopleService.GetPerson:input_type -> ft.upp.people.v1.GetPersonRequest
+	2,  // 12: ft.upp.people.v1.PeopleService.BatchGetPeople:input_type -> ft.upp.people.v1.BatchGetPeopleRequest
+	4,  // 13: ft.upp.people.v1.PeopleService.StreamPeople:input_type -> ft.upp.people.v1.StreamPeopleRequest
+	6,  // 14: ft.upp.people.v1.PeopleService.GetPerson:output_type -> ft.upp.people.v1.Person
+	3,  // 15: ft.upp.people.v1.PeopleService.BatchGetPeople:output_type -> ft.upp.people.v1.BatchGetPeopleResponse
+	5,  // 16: ft.upp.people.v1.PeopleService.StreamPeople:output_type -> ft.upp.people.v1.PersonResult
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_people_proto_init() }
+func file_people_proto_init() {
+	if File_people_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_people_proto_rawDesc), len(file_people_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_people_proto_goTypes,
+		DependencyIndexes: file_people_proto_depIdxs,
+		EnumInfos:         file_people_proto_enumTypes,
+		MessageInfos:      file_people_proto_msgTypes,
+	}.Build()
+	File_people_proto = out.File
+	file_people_proto_goTypes = nil
+	file_people_proto_depIdxs = nil
+}
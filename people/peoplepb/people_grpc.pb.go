@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: people.proto
+
+package peoplepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PeopleService_GetPerson_FullMethodName      = "/ft.upp.people.v1.PeopleService/GetPerson"
+	PeopleService_BatchGetPeople_FullMethodName = "/ft.upp.people.v1.PeopleService/BatchGetPeople"
+	PeopleService_StreamPeople_FullMethodName   = "/ft.upp.people.v1.PeopleService/StreamPeople"
+)
+
+// PeopleServiceClient is the client API for PeopleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PeopleService exposes the same people as the REST /people/{uuid} endpoint.
+type PeopleServiceClient interface {
+	// GetPerson returns a single person. Concorded UUIDs resolve to the canonical person.
+	GetPerson(ctx context.Context, in *GetPersonRequest, opts ...grpc.CallOption) (*Person, error)
+	// BatchGetPeople returns a result for every requested UUID, in request order.
+	BatchGetPeople(ctx context.Context, in *BatchGetPeopleRequest, opts ...grpc.CallOption) (*BatchGetPeopleResponse, error)
+	// StreamPeople streams a result for every requested UUID, sending each one as its lookup completes.
+	StreamPeople(ctx context.Context, in *StreamPeopleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PersonResult], error)
+}
+
+type peopleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPeopleServiceClient(cc grpc.ClientConnInterface) PeopleServiceClient {
+	return &peopleServiceClient{cc}
+}
+
+func (c *peopleServiceClient) GetPerson(ctx context.Context, in *GetPersonRequest, opts ...grpc.CallOption) (*Person, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Person)
+	err := c.cc.Invoke(ctx, PeopleService_GetPerson_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peopleServiceClient) BatchGetPeople(ctx context.Context, in *BatchGetPeopleRequest, opts ...grpc.CallOption) (*BatchGetPeopleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetPeopleResponse)
+	err := c.cc.Invoke(ctx, PeopleService_BatchGetPeople_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peopleServiceClient) StreamPeople(ctx context.Context, in *StreamPeopleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PersonResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PeopleService_ServiceDesc.Streams[0], PeopleService_StreamPeople_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPeopleRequest, PersonResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PeopleService_StreamPeopleClient = grpc.ServerStreamingClient[PersonResult]
+
+// PeopleServiceServer is the server API for PeopleService service.
+// All implementations must embed UnimplementedPeopleServiceServer
+// for forward compatibility.
+//
+// PeopleService exposes the same people as the REST /people/{uuid} endpoint.
+type PeopleServiceServer interface {
+	// GetPerson returns a single person. Concorded UUIDs resolve to the canonical person.
+	GetPerson(context.Context, *GetPersonRequest) (*Person, error)
+	// BatchGetPeople returns a result for every requested UUID, in request order.
+	BatchGetPeople(context.Context, *BatchGetPeopleRequest) (*BatchGetPeopleResponse, error)
+	// StreamPeople streams a result for every requested UUID, sending each one as its lookup completes.
+	StreamPeople(*StreamPeopleRequest, grpc.ServerStreamingServer[PersonResult]) error
+	mustEmbedUnimplementedPeopleServiceServer()
+}
+
+// UnimplementedPeopleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPeopleServiceServer struct{}
+
+func (UnimplementedPeopleServiceServer) GetPerson(context.Context, *GetPersonRequest) (*Person, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPerson not implemented")
+}
+func (UnimplementedPeopleServiceServer) BatchGetPeople(context.Context, *BatchGetPeopleRequest) (*BatchGetPeopleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetPeople not implemented")
+}
+func (UnimplementedPeopleServiceServer) StreamPeople(*StreamPeopleRequest, grpc.ServerStreamingServer[PersonResult]) error {
+	return status.Error(codes.Unimplemented, "method StreamPeople not implemented")
+}
+func (UnimplementedPeopleServiceServer) mustEmbedUnimplementedPeopleServiceServer() {}
+func (UnimplementedPeopleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePeopleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PeopleServiceServer will
+// result in compilation errors.
+type UnsafePeopleServiceServer interface {
+	mustEmbedUnimplementedPeopleServiceServer()
+}
+
+func RegisterPeopleServiceServer(s grpc.ServiceRegistrar, srv PeopleServiceServer) {
+	// If the following call panics, it indicates UnimplementedPeopleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PeopleService_ServiceDesc, srv)
+}
+
+func _PeopleService_GetPerson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeopleServiceServer).GetPerson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeopleService_GetPerson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeopleServiceServer).GetPerson(ctx, req.(*GetPersonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeopleService_BatchGetPeople_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPeopleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeopleServiceServer).BatchGetPeople(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeopleService_BatchGetPeople_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeopleServiceServer).BatchGetPeople(ctx, req.(*BatchGetPeopleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeopleService_StreamPeople_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPeopleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeopleServiceServer).StreamPeople(m, &grpc.GenericServerStream[StreamPeopleRequest, PersonResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PeopleService_StreamPeopleServer = grpc.ServerStreamingServer[PersonResult]
+
+// PeopleService_ServiceDesc is the grpc.ServiceDesc for PeopleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PeopleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ft.upp.people.v1.PeopleService",
+	HandlerType: (*PeopleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPerson",
+			Handler:    _PeopleService_GetPerson_Handler,
+		},
+		{
+			MethodName: "BatchGetPeople",
+			Handler:    _PeopleService_BatchGetPeople_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPeople",
+			Handler:       _PeopleService_StreamPeople_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "people.proto",
+}
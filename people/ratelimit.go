@@ -0,0 +1,251 @@
+package people
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/Financial-Times/transactionid-utils-go"
+)
+
+const (
+	rateLimitedMsg = "Rate limit exceeded, retry after %d seconds"
+
+	// anonymousClient labels the metrics of clients that have no configured limit, to keep their cardinality bounded
+	anonymousClient = "anonymous"
+
+	bucketSweepInterval = time.Minute
+)
+
+// RateLimit allows Rate requests per second on average, and bursts of up to Burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitClient is a client identified by its API key, or by its principal name when authentication is enabled,
+// with its own limit
+type RateLimitClient struct {
+	Name   string
+	APIKey string
+	Limit  RateLimit
+}
+
+// RateLimitConfig configures per client rate limiting. Authenticated clients are identified by their principal name,
+// other clients by the API key header when present, and by their IP address otherwise. A zero default rate disables
+// rate limiting for clients without their own limit.
+type RateLimitConfig struct {
+	KeyHeader         string
+	Default           RateLimit
+	Clients           []RateLimitClient
+	TrustForwardedFor bool
+}
+
+// ParseRateLimitClients parses clients separated by commas, in the form name:apikey:rate:burst, or name:rate:burst
+// for clients identified by their principal name
+func ParseRateLimitClients(specs string) ([]RateLimitClient, error) {
+	var clients []RateLimitClient
+	for _, spec := range strings.Split(specs, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		// errors name the client rather than quoting the spec, which holds its API key
+		parts := strings.Split(spec, ":")
+		var apiKey, rateSpec, burstSpec string
+		switch len(parts) {
+		case 3:
+			rateSpec, burstSpec = parts[1], parts[2]
+		case 4:
+			apiKey, rateSpec, burstSpec = parts[1], parts[2], parts[3]
+		}
+		if rateSpec == "" || parts[0] == "" || (len(parts) == 4 && apiKey == "") {
+			return nil, fmt.Errorf("invalid rate limit client %q, expected name:apikey:rate:burst or name:rate:burst", parts[0])
+		}
+		rate, err := strconv.ParseFloat(rateSpec, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate for rate limit client %s", parts[0])
+		}
+		burst, err := strconv.Atoi(burstSpec)
+		if err != nil || burst <= 0 {
+			return nil, fmt.Errorf("invalid burst for rate limit client %s", parts[0])
+		}
+		clients = append(clients, RateLimitClient{
+			Name:   parts[0],
+			APIKey: apiKey,
+			Limit:  RateLimit{Rate: rate, Burst: burst},
+		})
+	}
+	return clients, nil
+}
+
+// RateLimiter enforces a token bucket per client
+type RateLimiter struct {
+	config  RateLimitConfig
+	clients map[string]RateLimitClient
+	names   map[string]RateLimitClient
+	metrics *Metrics
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(config RateLimitConfig, metrics *Metrics) *RateLimiter {
+	clients := map[string]RateLimitClient{}
+	names := map[string]RateLimitClient{}
+	for _, c := range config.Clients {
+		if c.APIKey != "" {
+			clients[c.APIKey] = c
+		} else {
+			names[c.Name] = c
+		}
+	}
+	return &RateLimiter{
+		config:  config,
+		clients: clients,
+		names:   names,
+		metrics: metrics,
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Handler rejects requests over the limit of their client with a 429. Admin endpoints are never limited.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdminPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key, name, limit := l.client(r)
+		if limit.Rate <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, remaining, reset, retryAfter := l.take(key, limit)
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+		l.metrics.observeRateLimit(name, allowed)
+		if allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		transId := transactionidutils.GetTransactionIDFromRequest(r)
+		w.Header().Set("X-Request-Id", transId)
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+		logger.WithTransactionID(transId).WithField("client", name).Warn("Rate limit exceeded")
+		writeJSONStatus(w, fmt.Sprintf(rateLimitedMsg, ceilSeconds(retryAfter)), http.StatusTooManyRequests)
+	})
+}
+
+// client returns the bucket key, the metrics label and the limit of the client making the request. Authenticated
+// principals get a bucket of their own. Unknown API keys share the bucket of their IP address, as any client could
+// otherwise get a fresh bucket by sending a new key.
+func (l *RateLimiter) client(r *http.Request) (string, string, RateLimit) {
+	if principal, ok := principalFromContext(r.Context()); ok {
+		if c, ok := l.names[principal.Name]; ok {
+			return "principal:" + c.Name, c.Name, c.Limit
+		}
+		if principal.Name != anonymousPrincipal {
+			return "principal:" + principal.Name, anonymousClient, l.config.Default
+		}
+		return "ip:" + l.clientIP(r), anonymousClient, l.config.Default
+	}
+	if l.config.KeyHeader != "" {
+		if c, ok := l.clients[r.Header.Get(l.config.KeyHeader)]; ok {
+			return "client:" + c.Name, c.Name, c.Limit
+		}
+	}
+	return "ip:" + l.clientIP(r), anonymousClient, l.config.Default
+}
+
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.config.TrustForwardedFor {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			return strings.TrimSpace(strings.Split(fwd, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (l *RateLimiter) take(key string, limit RateLimit) (bool, int, time.Duration, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.lastSweep) > bucketSweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	return b.take(now, limit)
+}
+
+// sweep forgets the buckets that have refilled, as they are the same as new ones
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.idle(now) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+// take refills the bucket for the time elapsed and takes a token if there is one. It returns whether a token was
+// taken, the whole tokens left, the time until the bucket is full and the time until the next token.
+func (b *bucket) take(now time.Time, limit RateLimit) (bool, int, time.Duration, time.Duration) {
+	b.limit = limit
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	reset := secondsDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+	var retryAfter time.Duration
+	if !allowed {
+		retryAfter = secondsDuration((1 - b.tokens) / limit.Rate)
+	}
+	return allowed, int(b.tokens), reset, retryAfter
+}
+
+func (b *bucket) idle(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// isAdminPath reports whether a path is an admin or monitoring endpoint rather than part of the API
+func isAdminPath(path string) bool {
+	return strings.HasPrefix(path, "/__") || path == metricsPath
+}
@@ -0,0 +1,214 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rateLimitTest struct {
+	limiter *RateLimiter
+	handler http.Handler
+	now     time.Time
+}
+
+func newRateLimitTest(config RateLimitConfig, metrics *Metrics) *rateLimitTest {
+	logger.InitDefaultLogger("ratelimit-test")
+	test := &rateLimitTest{now: time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)}
+	test.limiter = NewRateLimiter(config, metrics)
+	test.limiter.now = func() time.Time { return test.now }
+	test.handler = test.limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return test
+}
+
+func (test *rateLimitTest) get(path, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	req := newRequest("GET", path, "")
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	test.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimiter_ByClientIP(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{Default: RateLimit{Rate: 0.5, Burst: 2}}, nil)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+
+	rec := test.get(path, "10.0.0.1:1234", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1235", nil).Code)
+
+	rec = test.get(path, "10.0.0.1:1236", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "4", rec.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, contentTypeJson, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"message": "Rate limit exceeded, retry after 2 seconds"}`, rec.Body.String())
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.2:1234", nil).Code, "other clients should have their own bucket")
+
+	test.now = test.now.Add(2 * time.Second)
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", nil).Code, "a token should be refilled after 2 seconds")
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.1:1234", nil).Code)
+}
+
+func TestRateLimiter_ByAPIKey(t *testing.T) {
+	metrics := NewMetrics()
+	test := newRateLimitTest(RateLimitConfig{
+		KeyHeader: "X-Api-Key",
+		Default:   RateLimit{Rate: 1, Burst: 1},
+		Clients:   []RateLimitClient{{Name: "next", APIKey: "next-key", Limit: RateLimit{Rate: 10, Burst: 3}}},
+	}, metrics)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+
+	for i := 0; i < 3; i++ {
+		rec := test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": "next-key"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "3", rec.Header().Get("RateLimit-Limit"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": "next-key"}).Code)
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": "other-key"}).Code, "unknown keys should have the default limit")
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.1:1234", nil).Code, "unknown keys should share the bucket of their IP")
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.2:1234", map[string]string{"X-Api-Key": "other-key"}).Code)
+
+	router := mux.NewRouter()
+	metrics.RegisterHandlers(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("GET", "/metrics", ""))
+	assert.Contains(t, rec.Body.String(), `public_people_api_rate_limit_requests_total{client="next",decision="allowed"} 3`)
+	assert.Contains(t, rec.Body.String(), `public_people_api_rate_limit_requests_total{client="next",decision="limited"} 1`)
+	assert.Contains(t, rec.Body.String(), `public_people_api_rate_limit_requests_total{client="anonymous",decision="allowed"} 2`)
+	assert.Contains(t, rec.Body.String(), `public_people_api_rate_limit_requests_total{client="anonymous",decision="limited"} 1`)
+	assert.NotContains(t, rec.Body.String(), "other-key")
+}
+
+func TestRateLimiter_UnknownAPIKeys(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{
+		KeyHeader: "X-Api-Key",
+		Default:   RateLimit{Rate: 1, Burst: 5},
+		Clients:   []RateLimitClient{{Name: "next", APIKey: "next-key", Limit: RateLimit{Rate: 10, Burst: 3}}},
+	}, nil)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+
+	limited := 0
+	for i := 0; i < 100; i++ {
+		rec := test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": fmt.Sprintf("fake-key-%d", i)})
+		if rec.Code == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+	assert.Equal(t, 95, limited, "a new fake key on each request should not get around the limit of the IP")
+	assert.Len(t, test.limiter.buckets, 1)
+}
+
+func TestRateLimiter_ByPrincipal(t *testing.T) {
+	metrics := NewMetrics()
+	test := newRateLimitTest(RateLimitConfig{
+		KeyHeader: "X-Api-Key",
+		Default:   RateLimit{Rate: 1, Burst: 1},
+		Clients:   []RateLimitClient{{Name: "next", Limit: RateLimit{Rate: 10, Burst: 3}}},
+	}, metrics)
+	test.handler = NewAuthenticator(AuthConfig{KeyHeader: "X-Api-Key", APIKeys: authTestKeys}).Handler(test.handler)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, test.get(path, fmt.Sprintf("10.0.0.%d:1234", i), map[string]string{"X-Api-Key": "next-key"}).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.9:1234", map[string]string{"X-Api-Key": "next-key"}).Code,
+		"the limit should follow the principal across addresses")
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": "spark-key"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.2:1234", map[string]string{"X-Api-Key": "spark-key"}).Code,
+		"other principals should get a bucket of their own with the default limit")
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.1:1234", nil).Code)
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.2:1234", nil).Code, "anonymous clients should be limited by address")
+
+	assert.Equal(t, http.StatusUnauthorized, test.get(path, "10.0.0.1:1234", map[string]string{"X-Api-Key": "fake-key"}).Code)
+	assert.Len(t, test.limiter.buckets, 4)
+
+	router := mux.NewRouter()
+	metrics.RegisterHandlers(router)
+	assert.Contains(t, scrapeMetrics(router), `public_people_api_rate_limit_requests_total{client="next",decision="limited"} 1`)
+}
+
+func TestRateLimiter_TrustForwardedFor(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}, TrustForwardedFor: true}, nil)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "192.0.2.1, 10.0.0.1"}).Code)
+	assert.Equal(t, http.StatusOK, test.get(path, "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "192.0.2.2, 10.0.0.1"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, test.get(path, "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "192.0.2.1"}).Code)
+}
+
+func TestRateLimiter_AdminEndpointsAreNotLimited(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 1}}, nil)
+
+	for _, path := range []string{"/__gtg", "/__health", "/__ready", "/metrics", "/__gtg"} {
+		rec := test.get(path, "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"), path)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{KeyHeader: "X-Api-Key"}, nil)
+	for i := 0; i < 10; i++ {
+		rec := test.get("/people/60e54253-1e94-38df-83b1-a39804d1ac18", "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	test := newRateLimitTest(RateLimitConfig{Default: RateLimit{Rate: 1, Burst: 5}}, nil)
+	path := "/people/60e54253-1e94-38df-83b1-a39804d1ac18"
+	test.get(path, "10.0.0.1:1234", nil)
+	test.get(path, "10.0.0.2:1234", nil)
+	require.Len(t, test.limiter.buckets, 2)
+
+	test.now = test.now.Add(2 * bucketSweepInterval)
+	test.get(path, "10.0.0.3:1234", nil)
+	assert.Len(t, test.limiter.buckets, 1)
+}
+
+func TestParseRateLimitClients(t *testing.T) {
+	clients, err := ParseRateLimitClients("next:key-1:10:20, spark:key-2:0.5:1")
+	require.NoError(t, err)
+	assert.Equal(t, []RateLimitClient{
+		{Name: "next", APIKey: "key-1", Limit: RateLimit{Rate: 10, Burst: 20}},
+		{Name: "spark", APIKey: "key-2", Limit: RateLimit{Rate: 0.5, Burst: 1}},
+	}, clients)
+
+	clients, err = ParseRateLimitClients("next:10:20")
+	require.NoError(t, err)
+	assert.Equal(t, []RateLimitClient{{Name: "next", Limit: RateLimit{Rate: 10, Burst: 20}}}, clients)
+
+	clients, err = ParseRateLimitClients("")
+	assert.NoError(t, err)
+	assert.Empty(t, clients)
+
+	for _, spec := range []string{"next:secret-key:10", "next:secret-key:fast:20", "next:secret-key:10:0", ":secret-key:1:1", "next::1:1", "next:10"} {
+		_, err := ParseRateLimitClients(spec)
+		assert.Error(t, err, spec)
+		assert.NotContains(t, err.Error(), "secret-key", "errors should not reveal API keys")
+	}
+}
@@ -0,0 +1,278 @@
+package people
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RedactHide removes a field from the person
+	RedactHide = "hide"
+	// RedactMask replaces the value of a text field with redactedValue. Other fields are hidden.
+	RedactMask = "mask"
+
+	redactedValue = "[REDACTED]"
+	allFields     = "*"
+)
+
+// redactableFields are the person fields, by JSON name, a redaction policy can hide or mask.
+// The description covers every rendering of it, and imageUrl the image set derived from it.
+var redactableFields = []string{
+	"prefLabel", "labels", "salutation", "birthYear", "emailAddress", "twitterHandle", "facebookProfile",
+	"description", "imageUrl", "memberships",
+}
+
+// redactableFieldAliases are the names the fields have in other versions of the representation
+var redactableFieldAliases = map[string]string{
+	"alternativeLabels": "labels",
+}
+
+// RedactionRule hides or masks fields of a person. A rule applies to every person and client unless it lists the
+// UUIDs of the people or the names of the clients it is restricted to.
+type RedactionRule struct {
+	Fields  []string `yaml:"fields"`
+	Action  string   `yaml:"action"`
+	People  []string `yaml:"people"`
+	Clients []string `yaml:"clients"`
+	// Reason records why the rule exists, such as the reference of a data-protection request
+	Reason string `yaml:"reason"`
+}
+
+// RedactionPolicy is a set of redaction rules, loaded from a YAML or JSON file of the form
+//
+//	rules:
+//	  - fields: [emailAddress]
+//	    action: mask
+//	  - people: [2d3e16e0-61cb-4322-8aff-3b01c59f4daa]
+//	    fields: ["*"]
+//	    reason: DPR-123
+//	  - clients: [spark]
+//	    fields: [birthYear]
+type RedactionPolicy struct {
+	rules []RedactionRule
+}
+
+func LoadRedactionPolicyFile(path string) (*RedactionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read redaction policy: %w", err)
+	}
+	return ParseRedactionPolicy(data)
+}
+
+func ParseRedactionPolicy(data []byte) (*RedactionPolicy, error) {
+	var doc struct {
+		Rules []RedactionRule `yaml:"rules"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid redaction policy: %w", err)
+	}
+
+	policy := &RedactionPolicy{}
+	var errs []error
+	for i, rule := range doc.Rules {
+		if rule.Action == "" {
+			rule.Action = RedactHide
+		}
+		if rule.Action != RedactHide && rule.Action != RedactMask {
+			errs = append(errs, fmt.Errorf("rule %d: unknown action %q, expected %s or %s", i+1, rule.Action, RedactHide, RedactMask))
+		}
+		if len(rule.Fields) == 0 {
+			errs = append(errs, fmt.Errorf("rule %d: no fields", i+1))
+		}
+		fields := make([]string, 0, len(rule.Fields))
+		for _, field := range rule.Fields {
+			name, ok := redactableField(field)
+			if !ok {
+				errs = append(errs, fmt.Errorf("rule %d: field %q cannot be redacted, expected one of %s or %s",
+					i+1, field, strings.Join(redactableFields, ", "), allFields))
+				continue
+			}
+			fields = append(fields, name)
+		}
+		rule.Fields = fields
+		for _, uuid := range rule.People {
+			if !IsValidUUID(uuid) {
+				errs = append(errs, fmt.Errorf("rule %d: invalid person UUID %q", i+1, uuid))
+			}
+		}
+		policy.rules = append(policy.rules, rule)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func redactableField(field string) (string, bool) {
+	if alias, ok := redactableFieldAliases[field]; ok {
+		field = alias
+	}
+	if field == allFields || containsString(redactableFields, field) {
+		return field, true
+	}
+	return "", false
+}
+
+// Len returns the number of rules of the policy
+func (p *RedactionPolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.rules)
+}
+
+// HasClientRules reports whether any rule is restricted to clients, which are only known when authentication is enabled
+func (p *RedactionPolicy) HasClientRules() bool {
+	if p == nil {
+		return false
+	}
+	for _, rule := range p.rules {
+		if len(rule.Clients) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldRedactions maps the fields to redact to their action
+type fieldRedactions map[string]string
+
+func (r fieldRedactions) add(field, action string) {
+	if field == allFields {
+		for _, f := range redactableFields {
+			r.add(f, action)
+		}
+		return
+	}
+	// hiding wins over masking
+	if r[field] != RedactHide {
+		r[field] = action
+	}
+}
+
+// redactions returns the fields to redact for any of the UUIDs of a person, for a client. The client is empty
+// when it is unknown, in which case rules restricted to clients do not apply.
+func (p *RedactionPolicy) redactions(uuids []string, client string) fieldRedactions {
+	r := fieldRedactions{}
+	if p == nil {
+		return r
+	}
+	for _, rule := range p.rules {
+		if len(rule.People) > 0 && !containsAny(rule.People, uuids) {
+			continue
+		}
+		if len(rule.Clients) > 0 && (client == "" || !containsString(rule.Clients, client)) {
+			continue
+		}
+		for _, field := range rule.Fields {
+			r.add(field, rule.Action)
+		}
+	}
+	return r
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, c := range candidates {
+		if containsString(values, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactConcept applies the redaction policy, for the client of the request, to a person concept requested by any
+// of the UUIDs.
+// Redaction happens before the concept is converted, so redacted values never reach responses, derived fields such as
+// the image set and rendered descriptions, or the logs. The fields outside the scopes of the client are removed from
+// the converted person by filterPerson.
+func (h *Handler) redactConcept(ctx context.Context, uuids []string, c Concept) (Concept, bool) {
+	client := ""
+	if principal, ok := principalFromContext(ctx); ok {
+		client = principal.Name
+	}
+	canonicalId := strings.TrimPrefix(convertID(c.ID), urlPrefix)
+	r := h.redactionPolicy.redactions(append(append([]string{}, uuids...), canonicalId), client)
+	if len(r) == 0 {
+		return c, false
+	}
+	return r.apply(c), true
+}
+
+// apply returns a copy of the concept with the fields redacted
+func (r fieldRedactions) apply(c Concept) Concept {
+	text := func(field string, s *string) {
+		switch r[field] {
+		case RedactHide:
+			*s = ""
+		case RedactMask:
+			if *s != "" {
+				*s = redactedValue
+			}
+		}
+	}
+	text("prefLabel", &c.PrefLabel)
+	text("salutation", &c.Salutation)
+	text("description", &c.Description)
+	text("description", &c.DescriptionXML)
+	if r["birthYear"] != "" {
+		c.BirthYear = 0
+	}
+	if r["imageUrl"] != "" {
+		c.ImageURL = ""
+	}
+	if r["memberships"] != "" {
+		c.RelatedConcepts = nil
+	}
+
+	if action := r["labels"]; action != "" {
+		var labels []TypedValue
+		if action == RedactMask {
+			for _, label := range c.AlternativeLabels {
+				labels = append(labels, TypedValue{Type: label.Type, Value: redactedValue})
+			}
+		}
+		c.AlternativeLabels = labels
+	}
+
+	var accounts []TypedValue
+	for _, account := range c.Account {
+		switch r[accountField(account.Type)] {
+		case RedactHide:
+			continue
+		case RedactMask:
+			account.Value = redactedValue
+		}
+		accounts = append(accounts, account)
+	}
+	c.Account = accounts
+	return c
+}
+
+// logSafeJSONError describes an error decoding an upstream concept without quoting any of its values, as they may
+// be redacted
+func logSafeJSONError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		kind := strings.Fields(typeErr.Value)
+		if len(kind) > 0 {
+			return fmt.Errorf("json: cannot unmarshal %s into field %s of type %s", kind[0], typeErr.Field, typeErr.Type)
+		}
+		return fmt.Errorf("json: cannot unmarshal into field %s of type %s", typeErr.Field, typeErr.Type)
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("json: syntax error at offset %d", syntaxErr.Offset)
+	}
+	return err
+}
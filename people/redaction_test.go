@@ -0,0 +1,203 @@
+package people
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+const suppressedUUID = "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+
+func newRedactionTestRouter(t *testing.T, policy string, auth *AuthConfig) http.Handler {
+	p, err := ParseRedactionPolicy([]byte(policy))
+	require.NoError(t, err)
+	router := newTestRouter(time.Minute, WithRedactionPolicy(p))
+	if auth != nil {
+		return NewAuthenticator(*auth).Handler(router)
+	}
+	return router
+}
+
+func TestParseRedactionPolicy(t *testing.T) {
+	yamlPolicy, err := ParseRedactionPolicy([]byte(`
+rules:
+  - fields: [emailAddress, alternativeLabels]
+    action: mask
+  - people: [` + suppressedUUID + `]
+    fields: ["*"]
+    reason: DPR-123
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, yamlPolicy.Len())
+	assert.False(t, yamlPolicy.HasClientRules())
+	assert.Equal(t, fieldRedactions{"emailAddress": RedactMask, "labels": RedactMask}, yamlPolicy.redactions([]string{authTestUUID}, ""))
+
+	jsonPolicy, err := ParseRedactionPolicy([]byte(`{"rules": [{"clients": ["spark"], "fields": ["birthYear"]}]}`))
+	require.NoError(t, err)
+	assert.True(t, jsonPolicy.HasClientRules())
+	assert.Empty(t, jsonPolicy.redactions([]string{authTestUUID}, ""))
+	assert.Equal(t, fieldRedactions{"birthYear": RedactHide}, jsonPolicy.redactions([]string{authTestUUID}, "spark"))
+
+	empty, err := ParseRedactionPolicy(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Len())
+
+	_, err = ParseRedactionPolicy([]byte(`
+rules:
+  - fields: [emailAddress, id]
+    action: blur
+  - people: [not-a-uuid]
+    fields: []
+`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rule 1: unknown action "blur"`)
+	assert.Contains(t, err.Error(), `rule 1: field "id" cannot be redacted`)
+	assert.Contains(t, err.Error(), `rule 2: invalid person UUID "not-a-uuid"`)
+	assert.Contains(t, err.Error(), "rule 2: no fields")
+
+	_, err = ParseRedactionPolicy([]byte(`{"rules": [{"field": ["birthYear"]}]}`))
+	assert.Error(t, err, "unknown keys should be rejected")
+}
+
+func TestRedactionPolicy_HideWinsOverMask(t *testing.T) {
+	policy, err := ParseRedactionPolicy([]byte(`
+rules:
+  - fields: ["*"]
+    action: mask
+  - people: [` + suppressedUUID + `]
+    fields: [prefLabel]
+`))
+	require.NoError(t, err)
+	r := policy.redactions([]string{authTestUUID, suppressedUUID}, "")
+	assert.Equal(t, RedactHide, r["prefLabel"])
+	assert.Equal(t, RedactMask, r["salutation"])
+}
+
+func TestRedaction_Global(t *testing.T) {
+	logger.InitDefaultLogger("redaction-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	handler := newRedactionTestRouter(t, `
+rules:
+  - fields: [emailAddress, labels, description]
+    action: mask
+  - fields: [birthYear, twitterHandle, imageUrl]
+`, nil)
+
+	rec, person := getAuthTestPerson(t, handler, "/people/"+authTestUUID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Neil Cole", person["prefLabel"])
+	assert.Equal(t, redactedValue, person["emailAddress"])
+	assert.Equal(t, []interface{}{redactedValue}, person["labels"])
+	assert.Equal(t, redactedValue, person["descriptionXML"])
+	assert.Equal(t, "https://www.facebook.com/financialtimes/", person["facebookProfile"])
+	assert.NotContains(t, person, "birthYear")
+	assert.NotContains(t, person, "twitterHandle")
+	assert.NotContains(t, person, "_imageUrl")
+	assert.NotContains(t, person, "imageSet")
+
+	rec, person = getAuthTestPerson(t, handler, "/v2/people/"+authTestUUID+"?descriptionFormat=text", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, redactedValue, person["descriptionText"])
+	assert.Equal(t, map[string]interface{}{"emailAddress": redactedValue, "facebookProfile": "https://www.facebook.com/financialtimes/"}, person["accounts"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "http://www.ft.com/ontology/Alias", "value": redactedValue}}, person["alternativeLabels"])
+}
+
+func TestRedaction_SuppressedPerson(t *testing.T) {
+	logger.InitDefaultLogger("redaction-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+suppressedUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, suppressedUUID, suppressedUUID, "")))
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	handler := newRedactionTestRouter(t, `
+rules:
+  - people: [`+suppressedUUID+`]
+    fields: ["*"]
+`, nil)
+
+	rec, person := getAuthTestPerson(t, handler, "/people/"+suppressedUUID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]interface{}{
+		"id":         "http://api.ft.com/things/" + suppressedUUID,
+		"apiUrl":     "http://api.ft.com/people/" + suppressedUUID,
+		"types":      []interface{}{"http://www.ft.com/ontology/core/Thing", "http://www.ft.com/ontology/concept/Concept", "http://www.ft.com/ontology/person/Person"},
+		"directType": "http://www.ft.com/ontology/person/Person",
+	}, person)
+
+	_, person = getAuthTestPerson(t, handler, "/people/"+authTestUUID, nil)
+	assert.Equal(t, "Neil Cole", person["prefLabel"], "other people should not be redacted")
+}
+
+func TestRedaction_PerClient(t *testing.T) {
+	logger.InitDefaultLogger("redaction-test")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID,
+		httpmock.NewStringResponder(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, authTestUUID, authTestUUID, "")))
+
+	handler := newRedactionTestRouter(t, `
+rules:
+  - clients: [next]
+    fields: [emailAddress]
+    action: mask
+`, &AuthConfig{KeyHeader: "X-Api-Key", APIKeys: authTestKeys})
+
+	_, person := getAuthTestPerson(t, handler, "/people/"+authTestUUID, map[string]string{"X-Api-Key": "next-key"})
+	assert.Equal(t, redactedValue, person["emailAddress"])
+	assert.EqualValues(t, 1957, person["birthYear"])
+
+	_, person = getAuthTestPerson(t, handler, "/people/"+authTestUUID, map[string]string{"X-Api-Key": "spark-key"})
+	assert.NotContains(t, person, "emailAddress", "fields outside the scopes of the client should stay hidden")
+	assert.Equal(t, "@ft", person["twitterHandle"])
+}
+
+func TestRedaction_Logs(t *testing.T) {
+	logger.InitDefaultLogger("redaction-test")
+	var out bytes.Buffer
+	logger.Logger().Out = &out
+	defer func() { logger.Logger().Out = os.Stderr }()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	handler := newRedactionTestRouter(t, `
+rules:
+  - people: [`+suppressedUUID+`, `+authTestUUID+`]
+    fields: ["*"]
+`, nil)
+
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+suppressedUUID, httpmock.NewStringResponder(200, `{
+		"id": "http://www.ft.com/thing/`+suppressedUUID+`",
+		"type": "http://www.ft.com/ontology/person/Person",
+		"prefLabel": "Jane Doe",
+		"descriptionXML": "<p>Jane Doe lives at 1 Secret Street</b>"
+	}`))
+	rec, _ := getAuthTestPerson(t, handler, "/people/"+suppressedUUID+"?descriptionFormat=html", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+authTestUUID, httpmock.NewStringResponder(200, `{
+		"id": "http://www.ft.com/thing/`+authTestUUID+`",
+		"type": "http://www.ft.com/ontology/person/Person",
+		"birthYear": 1957.25
+	}`))
+	rec, _ = getAuthTestPerson(t, handler, "/people/"+authTestUUID, nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	assert.Contains(t, out.String(), "Error parsing json")
+	assert.NotContains(t, out.String(), "Secret Street")
+	assert.NotContains(t, out.String(), "Jane Doe")
+	assert.NotContains(t, out.String(), "1957.25")
+}
@@ -0,0 +1,62 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	redirectParam = "redirect"
+
+	unknownRedirectPolicyMsg = "Unknown redirect policy %s, expected 301, 308 or transparent"
+
+	canonicalIDHeader = "X-Canonical-Id"
+)
+
+// RedirectPolicy is how requests for a UUID concorded to another, canonical, person are answered
+type RedirectPolicy string
+
+const (
+	// RedirectMovedPermanently redirects to the canonical person with a 301
+	RedirectMovedPermanently RedirectPolicy = "301"
+	// RedirectPermanent redirects to the canonical person with a 308
+	RedirectPermanent RedirectPolicy = "308"
+	// RedirectTransparent serves the canonical person, identified by the Content-Location and X-Canonical-Id headers
+	RedirectTransparent RedirectPolicy = "transparent"
+)
+
+func ParseRedirectPolicy(policy string) (RedirectPolicy, error) {
+	switch p := RedirectPolicy(policy); p {
+	case RedirectMovedPermanently, RedirectPermanent, RedirectTransparent:
+		return p, nil
+	}
+	return "", fmt.Errorf(unknownRedirectPolicyMsg, policy)
+}
+
+func (p RedirectPolicy) status() int {
+	if p == RedirectPermanent {
+		return http.StatusPermanentRedirect
+	}
+	return http.StatusMovedPermanently
+}
+
+// redirectPolicy returns the policy requested in the query, or the configured one
+func (h *Handler) redirectPolicy(r *http.Request) (RedirectPolicy, error) {
+	if requested := r.URL.Query().Get(redirectParam); requested != "" {
+		return ParseRedirectPolicy(requested)
+	}
+	if h.redirects == "" {
+		return RedirectMovedPermanently, nil
+	}
+	return h.redirects, nil
+}
+
+// canonicalURL is the request URL for the canonical UUID, keeping every query parameter
+func canonicalURL(u *url.URL, uuid, canonicalId string) string {
+	canonical := *u
+	canonical.Path = strings.Replace(u.Path, uuid, canonicalId, 1)
+	canonical.RawPath = ""
+	return canonical.String()
+}
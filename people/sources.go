@@ -0,0 +1,244 @@
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ConceptSourceHTTP, ConceptSourceFile and ConceptSourceMemory name the sources of concepts
+	ConceptSourceHTTP   = "http"
+	ConceptSourceFile   = "file"
+	ConceptSourceMemory = "memory"
+
+	// ConcordancesFile is the file of a concepts directory mapping concorded UUIDs to their canonical UUID
+	ConcordancesFile = "concordances.json"
+)
+
+// ErrConceptNotFound is returned by a ConceptSource without a concept for the UUID
+var ErrConceptNotFound = errors.New("Not found")
+
+// ConceptSource reads concepts by UUID. A concorded UUID reads the concept of its canonical UUID, as
+// public-concepts-api does.
+type ConceptSource interface {
+	GetConcept(ctx context.Context, uuid string) (Concept, error)
+}
+
+// WithConceptSource reads concepts from the source rather than from public-concepts-api
+func WithConceptSource(source ConceptSource) HandlerOption {
+	return func(h *Handler) {
+		h.source = source
+	}
+}
+
+// FileConceptSource reads concepts from a directory of <uuid>.json files, as they are when requested, with the
+// concorded UUIDs in ConcordancesFile
+type FileConceptSource struct {
+	dir string
+}
+
+// NewFileConceptSource reads concepts from dir, which must be a directory
+func NewFileConceptSource(dir string) (*FileConceptSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	return &FileConceptSource{dir: dir}, nil
+}
+
+func (s *FileConceptSource) GetConcept(ctx context.Context, uuid string) (Concept, error) {
+	var c Concept
+	if !IsValidUUID(uuid) {
+		return c, ErrConceptNotFound
+	}
+	concordances, err := s.concordances()
+	if err != nil {
+		return c, err
+	}
+	if canonical, ok := concordances[uuid]; ok {
+		uuid = canonical
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, uuid+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, ErrConceptNotFound
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%s.json: %w", uuid, logSafeJSONError(err))
+	}
+	return c, nil
+}
+
+func (s *FileConceptSource) concordances() (map[string]string, error) {
+	concordances := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(s.dir, ConcordancesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return concordances, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &concordances); err != nil {
+		return nil, fmt.Errorf("%s: %w", ConcordancesFile, logSafeJSONError(err))
+	}
+	return concordances, nil
+}
+
+func (s *FileConceptSource) String() string {
+	return "file://" + s.dir
+}
+
+// Checker checks the directory of concepts can be read
+func (s *FileConceptSource) Checker() (string, error) {
+	if _, err := os.ReadDir(s.dir); err != nil {
+		return "", err
+	}
+	if _, err := s.concordances(); err != nil {
+		return "", err
+	}
+	return "Concepts directory is readable", nil
+}
+
+// MemoryConceptSource serves concepts held in memory
+type MemoryConceptSource struct {
+	mu       sync.RWMutex
+	concepts map[string]Concept
+}
+
+// NewMemoryConceptSource serves the concepts, each by the UUID of its ID
+func NewMemoryConceptSource(concepts ...Concept) *MemoryConceptSource {
+	s := &MemoryConceptSource{concepts: map[string]Concept{}}
+	for _, c := range concepts {
+		s.Put(conceptUUID(c), c)
+	}
+	return s
+}
+
+// LoadMemoryConceptSource reads concepts into memory once, from a directory like FileConceptSource, or from a JSON
+// file of an array of concepts
+func LoadMemoryConceptSource(path string) (*MemoryConceptSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var concepts []Concept
+		if err := json.Unmarshal(data, &concepts); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), logSafeJSONError(err))
+		}
+		return NewMemoryConceptSource(concepts...), nil
+	}
+
+	dir := &FileConceptSource{dir: path}
+	concordances, err := dir.concordances()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	s := NewMemoryConceptSource()
+	for _, file := range files {
+		if filepath.Base(file) == ConcordancesFile {
+			continue
+		}
+		uuid := strings.TrimSuffix(filepath.Base(file), ".json")
+		if !IsValidUUID(uuid) {
+			return nil, fmt.Errorf("%s: expected a file named after the UUID of its concept", filepath.Base(file))
+		}
+		c, err := dir.GetConcept(context.Background(), uuid)
+		if err != nil {
+			return nil, err
+		}
+		s.Put(uuid, c)
+	}
+	for uuid, canonical := range concordances {
+		c, err := dir.GetConcept(context.Background(), canonical)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s is concorded to %s, %w", ConcordancesFile, uuid, canonical, err)
+		}
+		s.Put(uuid, c)
+	}
+	return s, nil
+}
+
+func (s *MemoryConceptSource) GetConcept(ctx context.Context, uuid string) (Concept, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.concepts[uuid]
+	if !ok {
+		return Concept{}, ErrConceptNotFound
+	}
+	return c, nil
+}
+
+// Put serves the concept for the UUID, which is concorded to the concept when it is not the UUID of its ID
+func (s *MemoryConceptSource) Put(uuid string, c Concept) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.concepts[uuid] = c
+}
+
+// Len is the number of UUIDs served
+func (s *MemoryConceptSource) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.concepts)
+}
+
+func (s *MemoryConceptSource) String() string {
+	return "memory"
+}
+
+func conceptUUID(c Concept) string {
+	return strings.TrimPrefix(convertID(c.ID), urlPrefix)
+}
+
+// sourceName names the source of concepts in debug responses
+func sourceName(source ConceptSource) string {
+	if s, ok := source.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", source)
+}
+
+// fetchConcept returns the exchange the concept is read in. Sources other than public-concepts-api answer with
+// the concept they read, as JSON, or a 404.
+func (h *Handler) fetchConcept(ctx context.Context, uuid string) (upstreamResponse, error) {
+	if api, ok := h.source.(*HTTPConceptSource); ok {
+		return api.fetch(ctx, uuid)
+	}
+	upstream := upstreamResponse{URL: sourceName(h.source)}
+	start := time.Now()
+	c, err := h.source.GetConcept(ctx, uuid)
+	upstream.Duration = time.Since(start)
+	switch {
+	case errors.Is(err, ErrConceptNotFound):
+		upstream.Status = http.StatusNotFound
+		return upstream, nil
+	case err != nil:
+		return upstream, err
+	}
+	upstream.Status = http.StatusOK
+	upstream.Body, err = json.Marshal(c)
+	return upstream, err
+}
@@ -0,0 +1,129 @@
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sourceUUID          = "60e54253-1e94-38df-83b1-a39804d1ac18"
+	sourceConcordedUUID = "70f4732b-7f7d-30a1-9c29-0cceec23760e"
+	sourceMissingUUID   = "2d3e16e0-61cb-4322-8aff-3b01c59f4daa"
+)
+
+// writeConceptsDir writes a directory of one person, and a UUID concorded to it
+func writeConceptsDir(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, sourceUUID+".json"),
+		[]byte(fmt.Sprintf(conceptAPICompleteResponseTemplate, sourceUUID, sourceUUID, "")), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ConcordancesFile),
+		[]byte(`{"`+sourceConcordedUUID+`":"`+sourceUUID+`"}`), 0o644))
+	return dir
+}
+
+func TestFileConceptSource(t *testing.T) {
+	dir := writeConceptsDir(t)
+	source, err := NewFileConceptSource(dir)
+	require.NoError(t, err)
+
+	for _, uuid := range []string{sourceUUID, sourceConcordedUUID} {
+		c, err := source.GetConcept(context.Background(), uuid)
+		require.NoError(t, err, uuid)
+		assert.Equal(t, "http://www.ft.com/thing/"+sourceUUID, c.ID, uuid)
+		assert.Equal(t, "Neil Cole", c.PrefLabel, uuid)
+	}
+	for _, uuid := range []string{sourceMissingUUID, "../" + sourceUUID} {
+		_, err = source.GetConcept(context.Background(), uuid)
+		assert.ErrorIs(t, err, ErrConceptNotFound, uuid)
+	}
+
+	status, err := source.Checker()
+	assert.NoError(t, err)
+	assert.Equal(t, "Concepts directory is readable", status)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, sourceMissingUUID+".json"), []byte(`{"id": 1}`), 0o644))
+	_, err = source.GetConcept(context.Background(), sourceMissingUUID)
+	assert.EqualError(t, err, sourceMissingUUID+".json: json: cannot unmarshal number into field id of type string")
+
+	_, err = NewFileConceptSource(filepath.Join(dir, sourceUUID+".json"))
+	assert.EqualError(t, err, filepath.Join(dir, sourceUUID+".json")+" is not a directory")
+}
+
+func TestMemoryConceptSource(t *testing.T) {
+	source, err := LoadMemoryConceptSource(writeConceptsDir(t))
+	require.NoError(t, err)
+	assert.Equal(t, 2, source.Len())
+	c, err := source.GetConcept(context.Background(), sourceConcordedUUID)
+	require.NoError(t, err)
+	assert.Equal(t, "http://www.ft.com/thing/"+sourceUUID, c.ID)
+
+	snapshot := filepath.Join(t.TempDir(), "people.json")
+	require.NoError(t, os.WriteFile(snapshot,
+		[]byte(`[`+fmt.Sprintf(conceptAPICompleteResponseTemplate, sourceUUID, sourceUUID, "")+`]`), 0o644))
+	source, err = LoadMemoryConceptSource(snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, 1, source.Len())
+	_, err = source.GetConcept(context.Background(), sourceUUID)
+	assert.NoError(t, err)
+	_, err = source.GetConcept(context.Background(), sourceMissingUUID)
+	assert.ErrorIs(t, err, ErrConceptNotFound)
+
+	source.Put(sourceMissingUUID, Concept{ID: "http://www.ft.com/thing/" + sourceMissingUUID})
+	_, err = source.GetConcept(context.Background(), sourceMissingUUID)
+	assert.NoError(t, err)
+}
+
+func TestHandler_MemoryConceptSource(t *testing.T) {
+	logger.InitDefaultLogger("sources-test")
+	var concept Concept
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(conceptAPICompleteResponseTemplate, sourceUUID, sourceUUID, "")), &concept))
+	source := NewMemoryConceptSource(concept)
+	source.Put(sourceConcordedUUID, concept)
+	handler := NewHandler(0, "http://localhost:8080", http.DefaultClient, WithConceptSource(source), WithDebugToken("secret"))
+	router := mux.NewRouter()
+	handler.RegisterHandlers(router)
+	handler.RegisterDebugHandlers(router)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := newRequest("GET", path, "")
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/people/" + sourceUUID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var person Person
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&person))
+	assert.Equal(t, getExpectedPerson(sourceUUID, false), person)
+
+	rec = serve("/people/" + sourceConcordedUUID)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/people/"+sourceUUID, rec.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusNotFound, serve("/people/"+sourceMissingUUID).Code)
+
+	rec = serve("/__debug/people/" + sourceUUID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var debug DebugPerson
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&debug))
+	assert.Equal(t, "memory", debug.Upstream.URL)
+	assert.Equal(t, http.StatusOK, debug.Upstream.Status)
+	assert.NotNil(t, debug.Person)
+
+	status, err := handler.Checker()
+	assert.NoError(t, err)
+	assert.Equal(t, "Concepts are read from memory", status)
+}
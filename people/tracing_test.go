@@ -0,0 +1,69 @@
+package people
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Financial-Times/go-logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestTracing(t *testing.T) {
+	logger.InitDefaultLogger("tracing-test")
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uuid := "60e54253-1e94-38df-83b1-a39804d1ac18"
+	var upstreamHeaders http.Header
+	httpmock.RegisterResponder("GET", "http://localhost:8080/concepts/"+uuid, func(req *http.Request) (*http.Response, error) {
+		upstreamHeaders = req.Header
+		return httpmock.NewStringResponse(200, fmt.Sprintf(conceptAPICompleteResponseTemplate, uuid, uuid, "")), nil
+	})
+
+	router := mux.NewRouter()
+	NewHandler(0, "http://localhost:8080", http.DefaultClient).RegisterHandlers(router)
+
+	req := newRequest("GET", "/people/"+uuid, "")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Request-Id", "tid_tracing")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	server, upstream, convert := spans["GetPerson"], spans["getConcept"], spans["convertToPerson"]
+	if !assert.NotNil(t, server) || !assert.NotNil(t, upstream) || !assert.NotNil(t, convert) {
+		return
+	}
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, server.SpanContext().SpanID(), upstream.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindClient, upstream.SpanKind())
+	assert.Equal(t, server.SpanContext().SpanID(), convert.Parent().SpanID())
+
+	assert.Equal(t, "tid_tracing", upstreamHeaders.Get("X-Request-Id"))
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", upstream.SpanContext().TraceID(), upstream.SpanContext().SpanID()), upstreamHeaders.Get("traceparent"))
+}
@@ -0,0 +1,123 @@
+package people
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	versionParam = "version"
+
+	contentTypeJsonV2 = "application/json; version=2; charset=UTF-8"
+)
+
+// apiVersion describes one version of the Person representation
+type apiVersion struct {
+	name        string
+	contentType string
+	convert     func(Concept, personExtras) interface{}
+}
+
+// personExtras holds the parts of a person that are not derived from the concept alone
+type personExtras struct {
+	ImageSet          *ImageSet
+	DescriptionFormat string
+	DescriptionHTML   string
+	DescriptionText   string
+}
+
+// renderDescription renders the description XML in the requested format. On malformed XML
+// a best-effort rendering is kept and the error returned.
+func (e *personExtras) renderDescription(descriptionXML string) error {
+	if descriptionXML == "" {
+		return nil
+	}
+	var err error
+	switch e.DescriptionFormat {
+	case descriptionFormatHTML:
+		e.DescriptionHTML, err = renderDescriptionHTML(descriptionXML)
+	case descriptionFormatText:
+		e.DescriptionText, err = renderDescriptionText(descriptionXML)
+	}
+	return err
+}
+
+// keepDescriptionXML reports whether the raw description XML should be served
+func (e personExtras) keepDescriptionXML() bool {
+	return e.DescriptionFormat == "" || e.DescriptionFormat == descriptionFormatXML
+}
+
+var (
+	apiVersion1 = apiVersion{
+		name:        "1",
+		contentType: contentTypeJson,
+		convert: func(c Concept, extras personExtras) interface{} {
+			var p Person
+			convertToPerson(c, &p)
+			p.ImageSet = extras.ImageSet
+			p.DescriptionHTML = extras.DescriptionHTML
+			p.DescriptionText = extras.DescriptionText
+			if !extras.keepDescriptionXML() {
+				p.DescriptionXML = ""
+			}
+			return p
+		},
+	}
+	apiVersion2 = apiVersion{
+		name:        "2",
+		contentType: contentTypeJsonV2,
+		convert: func(c Concept, extras personExtras) interface{} {
+			var p PersonV2
+			convertToPersonV2(c, &p)
+			p.ImageSet = extras.ImageSet
+			p.DescriptionHTML = extras.DescriptionHTML
+			p.DescriptionText = extras.DescriptionText
+			if !extras.keepDescriptionXML() {
+				p.DescriptionXML = ""
+			}
+			return p
+		},
+	}
+
+	apiVersions = map[string]apiVersion{
+		apiVersion1.name: apiVersion1,
+		apiVersion2.name: apiVersion2,
+	}
+)
+
+// negotiateVersion picks the representation for a request. A version in the path (e.g. /v2/people/{uuid})
+// takes precedence over a version parameter on an acceptable JSON media type in the Accept header,
+// e.g. "application/json; version=2". Requests that ask for neither get version 1.
+func negotiateVersion(r *http.Request) (apiVersion, error) {
+	if name, ok := mux.Vars(r)[versionParam]; ok {
+		return lookupVersion(name)
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		name, ok := params[versionParam]
+		if !ok {
+			continue
+		}
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			return lookupVersion(name)
+		}
+	}
+	return apiVersion1, nil
+}
+
+func lookupVersion(name string) (apiVersion, error) {
+	v, ok := apiVersions[name]
+	if !ok {
+		return apiVersion{}, fmt.Errorf(unsupportedVersionMsg, name)
+	}
+	return v, nil
+}